@@ -2,12 +2,16 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/MakazhanAlpamys/claudeshield/internal/audit"
+	"github.com/MakazhanAlpamys/claudeshield/internal/cliout"
 	"github.com/MakazhanAlpamys/claudeshield/internal/config"
 	"github.com/MakazhanAlpamys/claudeshield/internal/orchestrator"
 	"github.com/MakazhanAlpamys/claudeshield/internal/policy"
 	"github.com/MakazhanAlpamys/claudeshield/internal/sandbox"
+	"github.com/MakazhanAlpamys/claudeshield/internal/secrets"
+	"github.com/MakazhanAlpamys/claudeshield/internal/snapshot"
 	"github.com/spf13/cobra"
 )
 
@@ -30,7 +34,7 @@ var agentSpawnCmd = &cobra.Command{
 			return err
 		}
 
-		auditor, err := audit.NewLogger(cfg.Audit.LogDir)
+		auditor, err := audit.NewLoggerWithConfig(cfg.Audit)
 		if err != nil {
 			return err
 		}
@@ -75,7 +79,7 @@ var agentStopCmd = &cobra.Command{
 			return err
 		}
 
-		auditor, err := audit.NewLogger(cfg.Audit.LogDir)
+		auditor, err := audit.NewLoggerWithConfig(cfg.Audit)
 		if err != nil {
 			return err
 		}
@@ -113,7 +117,7 @@ var agentListCmd = &cobra.Command{
 			return err
 		}
 
-		auditor, err := audit.NewLogger(cfg.Audit.LogDir)
+		auditor, err := audit.NewLoggerWithConfig(cfg.Audit)
 		if err != nil {
 			return err
 		}
@@ -130,19 +134,118 @@ var agentListCmd = &cobra.Command{
 			return err
 		}
 
-		if len(sessions) == 0 {
+		format := outputFormat(cmd)
+
+		if len(sessions) == 0 && format == cliout.FormatTable {
 			fmt.Println("No active agents")
 			return nil
 		}
 
-		fmt.Printf("%-15s %-25s %-12s %s\n", "AGENT", "SESSION", "STATE", "WORKTREE")
-		fmt.Println("─────────────────────────────────────────────────────────────────────")
-
+		table := cliout.Table{Headers: []string{"AGENT", "SESSION", "STATE", "WORKTREE"}}
 		for _, s := range sessions {
-			fmt.Printf("%-15s %-25s %-12s %s\n",
-				s.AgentName, s.ID, s.State, s.WorktreeDir)
+			table.Rows = append(table.Rows, []string{s.AgentName, s.ID, string(s.State), s.WorktreeDir})
+		}
+
+		return cliout.Print(os.Stdout, format, table)
+	},
+}
+
+var agentSnapshotCmd = &cobra.Command{
+	Use:   "snapshot <name>",
+	Short: "Snapshot an agent's worktree, sandbox config, and policy into an immutable manifest",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agentName := args[0]
+		projectDir := getProjectDir(cmd)
+
+		cfg, err := config.Load(projectDir)
+		if err != nil {
+			return err
+		}
+
+		auditor, err := audit.NewLoggerWithConfig(cfg.Audit)
+		if err != nil {
+			return err
+		}
+		defer auditor.Close()
+
+		engine, err := sandbox.New(auditor, nil)
+		if err != nil {
+			return err
+		}
+		defer engine.Close()
+
+		orch := orchestrator.New(engine, auditor)
+		store := snapshot.NewStore(projectDir)
+
+		secretRefs := secrets.KeyNames(cfg.Secrets)
+
+		manifest, err := orch.CreateSnapshot(store, agentName, cfg.Sandbox, cfg.Rules, secretRefs)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("📸 Snapshot created: %s\n", manifest.Digest)
+		fmt.Printf("   Commit: %s\n", manifest.GitCommit)
+		return nil
+	},
+}
+
+var agentRestoreCmd = &cobra.Command{
+	Use:   "restore <digest|alias> <new-name>",
+	Short: "Restore a new agent from a snapshot manifest",
+	Long:  "Hydrates a new worktree/branch from the snapshot's commit and re-applies its sandbox config verbatim.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		digestOrAlias, newAgentName := args[0], args[1]
+		projectDir := getProjectDir(cmd)
+
+		cfg, err := config.Load(projectDir)
+		if err != nil {
+			return err
+		}
+
+		auditor, err := audit.NewLoggerWithConfig(cfg.Audit)
+		if err != nil {
+			return err
+		}
+		defer auditor.Close()
+
+		engine, err := sandbox.New(auditor, nil)
+		if err != nil {
+			return err
+		}
+		defer engine.Close()
+
+		orch := orchestrator.New(engine, auditor)
+		store := snapshot.NewStore(projectDir)
+
+		fmt.Printf("🔁 Restoring agent %q from snapshot %q...\n", newAgentName, digestOrAlias)
+		session, err := orch.RestoreAgent(cmd.Context(), store, digestOrAlias, projectDir, newAgentName)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Agent %q restored\n", newAgentName)
+		fmt.Printf("   Session:  %s\n", session.ID)
+		fmt.Printf("   Worktree: %s\n", session.WorktreeDir)
+		return nil
+	},
+}
+
+var agentAliasCmd = &cobra.Command{
+	Use:   "alias <digest> <name>",
+	Short: "Give a snapshot digest a short, memorable name",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		digest, name := args[0], args[1]
+		store := snapshot.NewStore(getProjectDir(cmd))
+
+		if err := store.Alias(digest, name); err != nil {
+			return err
 		}
 
+		fmt.Printf("✅ %s -> %s\n", name, digest)
 		return nil
 	},
 }
@@ -153,4 +256,7 @@ func init() {
 	agentCmd.AddCommand(agentSpawnCmd)
 	agentCmd.AddCommand(agentStopCmd)
 	agentCmd.AddCommand(agentListCmd)
+	agentCmd.AddCommand(agentSnapshotCmd)
+	agentCmd.AddCommand(agentRestoreCmd)
+	agentCmd.AddCommand(agentAliasCmd)
 }
@@ -5,16 +5,23 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/MakazhanAlpamys/claudeshield/internal/audit"
 	"github.com/MakazhanAlpamys/claudeshield/internal/config"
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
 	"github.com/spf13/cobra"
 )
 
 var auditCmd = &cobra.Command{
 	Use:   "audit [session-id]",
 	Short: "View audit logs",
-	Long:  "Displays audit log entries. Optionally filter by session ID.",
+	Long: "Displays audit log entries, optionally filtered by session ID, " +
+		"--since/--until, --event, --action, --grep, and --agent. --follow " +
+		"tails new entries as they're written, reopening the log file if it " +
+		"rotates onto a new one.",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		projectDir := getProjectDir(cmd)
 		cfg, err := config.Load(projectDir)
@@ -27,15 +34,27 @@ var auditCmd = &cobra.Command{
 			sessionFilter = args[0]
 		}
 
+		opts, err := buildQueryOpts(cmd, sessionFilter)
+		if err != nil {
+			return err
+		}
+
 		jsonOutput, _ := cmd.Flags().GetBool("json")
-		last, _ := cmd.Flags().GetInt("last")
+		follow, _ := cmd.Flags().GetBool("follow")
 
 		logDir := cfg.Audit.LogDir
 		if !filepath.IsAbs(logDir) {
 			logDir = filepath.Join(projectDir, logDir)
 		}
 
-		entries, err := audit.ReadSession(logDir, sessionFilter)
+		if follow {
+			printTableHeader(jsonOutput)
+			return audit.Follow(cmd.Context(), logDir, opts, func(e types.AuditEntry) {
+				printEntry(e, jsonOutput)
+			})
+		}
+
+		entries, err := audit.Query(logDir, opts)
 		if err != nil {
 			return fmt.Errorf("reading audit logs: %w", err)
 		}
@@ -45,43 +64,195 @@ var auditCmd = &cobra.Command{
 			return nil
 		}
 
-		// Apply --last filter
-		if last > 0 && last < len(entries) {
-			entries = entries[len(entries)-last:]
-		}
-
 		if jsonOutput {
 			enc := json.NewEncoder(os.Stdout)
 			enc.SetIndent("", "  ")
 			return enc.Encode(entries)
 		}
 
-		fmt.Printf("%-20s %-20s %-15s %-10s %-30s %s\n",
-			"TIME", "SESSION", "EVENT", "ACTION", "COMMAND", "REASON")
-		fmt.Println("───────────────────────────────────────────────────────────────────────────────────────────────────────")
-
+		printTableHeader(false)
 		for _, e := range entries {
-			command := e.Command
-			if len(command) > 30 {
-				command = command[:27] + "..."
+			printEntry(e, false)
+		}
+
+		return nil
+	},
+}
+
+var auditVerifyCmd = &cobra.Command{
+	Use:   "verify [session-id]",
+	Short: "Recompute the audit log's hash chain and report the first tampered entry",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectDir := getProjectDir(cmd)
+		cfg, err := config.Load(projectDir)
+		if err != nil {
+			return err
+		}
+
+		sessionFilter := ""
+		if len(args) > 0 {
+			sessionFilter = args[0]
+		}
+
+		logDir := cfg.Audit.LogDir
+		if !filepath.IsAbs(logDir) {
+			logDir = filepath.Join(projectDir, logDir)
+		}
+
+		results, err := audit.VerifyChain(logDir, sessionFilter)
+		if err != nil {
+			return fmt.Errorf("verifying audit logs: %w", err)
+		}
+		if len(results) == 0 {
+			fmt.Println("No audit log files found")
+			return nil
+		}
+
+		signWith, _ := cmd.Flags().GetString("sign-with")
+		verifyWith, _ := cmd.Flags().GetString("verify-with")
+
+		failed := false
+		for _, r := range results {
+			if r.Broken {
+				failed = true
+				fmt.Printf("❌ %s: chain broken at entry %d (byte offset %d): %s\n", r.File, r.BrokenIndex, r.ByteOffset, r.Reason)
+				continue
+			}
+
+			fmt.Printf("✅ %s: %d entries verified\n", r.File, r.EntriesOK)
+			if r.TerminalHash == "" {
+				continue
+			}
+
+			switch {
+			case signWith != "":
+				if err := audit.SignTerminalHash(r.File, signWith, r.TerminalHash); err != nil {
+					return fmt.Errorf("signing %s: %w", r.File, err)
+				}
+				fmt.Printf("   signed terminal hash %s\n", r.TerminalHash)
+			case verifyWith != "":
+				if err := audit.VerifyTerminalHash(r.File, verifyWith, r.TerminalHash); err != nil {
+					failed = true
+					fmt.Printf("   ❌ signature check failed: %v\n", err)
+				} else {
+					fmt.Println("   ✅ signature verified")
+				}
 			}
-			fmt.Printf("%-20s %-20s %-15s %-10s %-30s %s\n",
-				e.Timestamp.Format("15:04:05"),
-				truncate(e.SessionID, 20),
-				e.EventType,
-				e.Action,
-				command,
-				e.Reason,
-			)
 		}
 
+		if failed {
+			return fmt.Errorf("audit log verification failed")
+		}
 		return nil
 	},
 }
 
+// buildQueryOpts translates auditCmd's flags plus the positional session
+// filter into an audit.QueryOpts.
+func buildQueryOpts(cmd *cobra.Command, sessionFilter string) (audit.QueryOpts, error) {
+	opts := audit.QueryOpts{SessionID: sessionFilter}
+
+	opts.Last, _ = cmd.Flags().GetInt("last")
+	opts.Agent, _ = cmd.Flags().GetString("agent")
+
+	if since, _ := cmd.Flags().GetString("since"); since != "" {
+		t, err := parseTimeFlag(since)
+		if err != nil {
+			return opts, fmt.Errorf("--since: %w", err)
+		}
+		opts.Since = t
+	}
+
+	if until, _ := cmd.Flags().GetString("until"); until != "" {
+		t, err := parseTimeFlag(until)
+		if err != nil {
+			return opts, fmt.Errorf("--until: %w", err)
+		}
+		opts.Until = t
+	}
+
+	if event, _ := cmd.Flags().GetString("event"); event != "" {
+		opts.Events = strings.Split(event, ",")
+	}
+
+	if action, _ := cmd.Flags().GetString("action"); action != "" {
+		for _, a := range strings.Split(action, ",") {
+			opts.Actions = append(opts.Actions, types.PolicyAction(strings.TrimSpace(a)))
+		}
+	}
+
+	if grep, _ := cmd.Flags().GetString("grep"); grep != "" {
+		re, err := regexp.Compile(grep)
+		if err != nil {
+			return opts, fmt.Errorf("--grep: %w", err)
+		}
+		opts.Grep = re
+	}
+
+	return opts, nil
+}
+
+// parseTimeFlag accepts either a duration counting back from now (e.g.
+// "2h", matching --since 2h) or an absolute timestamp in one of a few
+// common layouts (e.g. --until 2024-01-01T00:00).
+func parseTimeFlag(s string) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+
+	layouts := []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q (want a duration like \"2h\" or a timestamp like \"2024-01-01T00:00\")", s)
+}
+
+func printTableHeader(jsonOutput bool) {
+	if jsonOutput {
+		return
+	}
+	fmt.Printf("%-20s %-20s %-15s %-10s %-30s %s\n",
+		"TIME", "SESSION", "EVENT", "ACTION", "COMMAND", "REASON")
+	fmt.Println("───────────────────────────────────────────────────────────────────────────────────────────────────────")
+}
+
+func printEntry(e types.AuditEntry, jsonOutput bool) {
+	if jsonOutput {
+		data, _ := json.Marshal(e)
+		fmt.Println(string(data))
+		return
+	}
+
+	command := e.Command
+	if len(command) > 30 {
+		command = command[:27] + "..."
+	}
+	fmt.Printf("%-20s %-20s %-15s %-10s %-30s %s\n",
+		e.Timestamp.Format("15:04:05"),
+		truncate(e.SessionID, 20),
+		e.EventType,
+		e.Action,
+		command,
+		e.Reason,
+	)
+}
+
 func init() {
 	auditCmd.Flags().Bool("json", false, "Output as JSON")
 	auditCmd.Flags().Int("last", 0, "Show last N entries")
+	auditCmd.Flags().String("since", "", `Only entries at or after this time, e.g. "2h" or "2024-01-01T00:00"`)
+	auditCmd.Flags().String("until", "", `Only entries at or before this time, e.g. "2024-01-01T00:00"`)
+	auditCmd.Flags().String("event", "", "Only entries whose event type contains one of these comma-separated terms")
+	auditCmd.Flags().String("action", "", "Only entries with one of these comma-separated actions (allow,block,deny,warn,audit,pause)")
+	auditCmd.Flags().String("grep", "", "Only entries whose command matches this regular expression")
+	auditCmd.Flags().String("agent", "", "Only entries from this agent name")
+	auditCmd.Flags().Bool("follow", false, "Tail new entries as they're written")
+
+	auditVerifyCmd.Flags().String("sign-with", "", "Sign the verified chain's terminal hash with this ed25519 private key file")
+	auditVerifyCmd.Flags().String("verify-with", "", "Check the chain's terminal hash signature against this ed25519 public key file")
+	auditCmd.AddCommand(auditVerifyCmd)
 }
 
 func truncate(s string, maxLen int) string {
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MakazhanAlpamys/claudeshield/internal/cliout"
+	"github.com/MakazhanAlpamys/claudeshield/internal/config"
+	"github.com/MakazhanAlpamys/claudeshield/internal/hub"
+	"github.com/spf13/cobra"
+)
+
+var hubCmd = &cobra.Command{
+	Use:   "hub",
+	Short: "Browse and install community policy bundles",
+}
+
+var hubListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List bundles available from the policy hub",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		h, err := newHub()
+		if err != nil {
+			return err
+		}
+
+		bundles, err := h.List(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		table := cliout.Table{Headers: []string{"NAME", "VERSION", "AUTHOR", "TAGS", "DESCRIPTION"}}
+		for _, b := range bundles {
+			table.Rows = append(table.Rows, []string{b.Name, b.Version, b.Author, strings.Join(b.Tags, ","), b.Description})
+		}
+		return cliout.Print(os.Stdout, outputFormat(cmd), table)
+	},
+}
+
+var hubInstallCmd = &cobra.Command{
+	Use:   "install <bundle>",
+	Short: "Install a bundle and pin it in the project config",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		projectDir := getProjectDir(cmd)
+
+		h, err := newHub()
+		if err != nil {
+			return err
+		}
+
+		bundle, err := h.Install(cmd.Context(), name)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load(projectDir)
+		if err != nil {
+			return err
+		}
+		cfg.Bundles = pinBundle(cfg.Bundles, name, bundle.Version)
+		if err := config.Save(projectDir, cfg); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Installed %s@%s and pinned it in %s\n", name, bundle.Version, config.ConfigFileName)
+		return nil
+	},
+}
+
+var hubUpdateCmd = &cobra.Command{
+	Use:   "update <bundle>",
+	Short: "Fetch a bundle's latest version",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		projectDir := getProjectDir(cmd)
+
+		h, err := newHub()
+		if err != nil {
+			return err
+		}
+
+		bundle, err := h.Update(cmd.Context(), name)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load(projectDir)
+		if err != nil {
+			return err
+		}
+		if pinnedName(cfg.Bundles, name) {
+			cfg.Bundles = pinBundle(cfg.Bundles, name, bundle.Version)
+			if err := config.Save(projectDir, cfg); err != nil {
+				return err
+			}
+		}
+
+		fmt.Printf("✅ Updated %s to %s\n", name, bundle.Version)
+		return nil
+	},
+}
+
+var hubRemoveCmd = &cobra.Command{
+	Use:   "remove <bundle>",
+	Short: "Remove a cached bundle and unpin it from the project config",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		projectDir := getProjectDir(cmd)
+
+		h, err := newHub()
+		if err != nil {
+			return err
+		}
+		if err := h.Remove(name); err != nil {
+			return err
+		}
+
+		cfg, err := config.Load(projectDir)
+		if err != nil {
+			return err
+		}
+		cfg.Bundles = unpinBundle(cfg.Bundles, name)
+		if err := config.Save(projectDir, cfg); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Removed %s\n", name)
+		return nil
+	},
+}
+
+var hubTrustCmd = &cobra.Command{
+	Use:   "trust <pubkey>",
+	Short: "Add a base64 ed25519 public key bundle signatures are checked against",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		h, err := newHub()
+		if err != nil {
+			return err
+		}
+		if err := h.TrustKey(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Trusted key added to %s\n", h.TrustedKeysPath())
+		return nil
+	},
+}
+
+func newHub() (*hub.Hub, error) {
+	globalDir, err := config.GlobalConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return hub.New(filepath.Join(globalDir, hub.CacheDirName)), nil
+}
+
+// pinBundle sets name's pin to name@version, replacing any existing pin
+// (bare or versioned) for the same bundle.
+func pinBundle(bundles []string, name, version string) []string {
+	out := unpinBundle(bundles, name)
+	return append(out, fmt.Sprintf("%s@%s", name, version))
+}
+
+func unpinBundle(bundles []string, name string) []string {
+	out := make([]string, 0, len(bundles))
+	for _, ref := range bundles {
+		if ref == name || strings.HasPrefix(ref, name+"@") {
+			continue
+		}
+		out = append(out, ref)
+	}
+	return out
+}
+
+func pinnedName(bundles []string, name string) bool {
+	for _, ref := range bundles {
+		if ref == name || strings.HasPrefix(ref, name+"@") {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	hubCmd.Annotations = map[string]string{"group": managementGroup}
+	hubCmd.AddCommand(hubListCmd)
+	hubCmd.AddCommand(hubInstallCmd)
+	hubCmd.AddCommand(hubUpdateCmd)
+	hubCmd.AddCommand(hubRemoveCmd)
+	hubCmd.AddCommand(hubTrustCmd)
+}
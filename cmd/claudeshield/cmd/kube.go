@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/MakazhanAlpamys/claudeshield/internal/audit"
+	"github.com/MakazhanAlpamys/claudeshield/internal/config"
+	"github.com/MakazhanAlpamys/claudeshield/internal/sandbox"
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var kubeCmd = &cobra.Command{
+	Use:   "kube",
+	Short: "Export sessions as Kubernetes manifests",
+}
+
+var kubeGenerateCmd = &cobra.Command{
+	Use:   "generate <session-id>",
+	Short: "Generate a Pod/Secret/ConfigMap manifest for a running session",
+	Long:  "Serializes a running session's container into a Kubernetes Pod, plus a Secret for its env secrets and a ConfigMap for its policy.json, so the same guardrails travel with the agent into a cluster.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sessionID := args[0]
+		projectDir := getProjectDir(cmd)
+
+		cfg, err := config.Load(projectDir)
+		if err != nil {
+			return err
+		}
+
+		auditor, err := audit.NewLoggerWithConfig(cfg.Audit)
+		if err != nil {
+			return err
+		}
+		defer auditor.Close()
+
+		engine, err := sandbox.New(auditor, nil)
+		if err != nil {
+			return err
+		}
+		defer engine.Close()
+
+		sessions, err := engine.ListSessions(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		var session *types.Session
+		for _, s := range sessions {
+			if s.ID == sessionID {
+				session = s
+				break
+			}
+		}
+		if session == nil {
+			return fmt.Errorf("session %q not found", sessionID)
+		}
+
+		manifest, err := engine.GenerateKube(cmd.Context(), session)
+		if err != nil {
+			return fmt.Errorf("generating manifest: %w", err)
+		}
+
+		outputFile, _ := cmd.Flags().GetString("output-file")
+		if outputFile == "" {
+			_, err = os.Stdout.Write(manifest)
+			return err
+		}
+		return os.WriteFile(outputFile, manifest, 0o644)
+	},
+}
+
+func init() {
+	kubeGenerateCmd.Flags().String("output-file", "", "Write the manifest to a file instead of stdout")
+	kubeCmd.AddCommand(kubeGenerateCmd)
+}
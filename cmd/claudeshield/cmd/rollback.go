@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
 	"github.com/MakazhanAlpamys/claudeshield/internal/audit"
 	"github.com/MakazhanAlpamys/claudeshield/internal/config"
@@ -27,7 +28,7 @@ var rollbackCmd = &cobra.Command{
 			return fmt.Errorf("loading config: %w", err)
 		}
 
-		auditor, err := audit.NewLogger(cfg.Audit.LogDir)
+		auditor, err := audit.NewLoggerWithConfig(cfg.Audit)
 		if err != nil {
 			return fmt.Errorf("creating auditor: %w", err)
 		}
@@ -39,7 +40,8 @@ var rollbackCmd = &cobra.Command{
 		}
 		defer engine.Close()
 
-		mgr := rollback.New(engine.Client())
+		storagePath := filepath.Join(projectDir, ".claudeshield", "checkpoints.json")
+		mgr := rollback.New(engine.Client(), storagePath)
 
 		ctx := context.Background()
 
@@ -4,11 +4,18 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/MakazhanAlpamys/claudeshield/internal/cliout"
 	"github.com/spf13/cobra"
 )
 
 const version = "0.1.0"
 
+// managementGroup annotates commands that configure or inspect state
+// (agent, secrets, hub) so the root help template can list them
+// under "Management Commands", separately from day-to-day session commands —
+// the same split docker's root command uses.
+const managementGroup = "management"
+
 var rootCmd = &cobra.Command{
 	Use:   "claudeshield",
 	Short: "ClaudeShield — secure sandbox for Claude Code agents",
@@ -17,29 +24,112 @@ with policy enforcement, secret protection, audit logging,
 and one-click rollback.
 
 Run Claude Code at full speed — without risking your machine or secrets.`,
-	SilenceUsage: true,
+	SilenceUsage:  true,
+	SilenceErrors: true,
 }
 
-func Execute() error {
-	return rootCmd.Execute()
+// Execute runs the root command and maps any returned error to a process
+// exit code: a *cliout.StatusError carries its own code, everything else
+// exits 1. Codes in the 125-130 taxonomy (see cliout.ExitDaemonUnreachable
+// etc.) describe a specific, scriptable cause of failure rather than a
+// usage mistake, so only the generic (code 1) case gets a "--help" hint.
+func Execute() int {
+	executedCmd, err := rootCmd.ExecuteC()
+	if err == nil {
+		return 0
+	}
+
+	statusErr, ok := err.(*cliout.StatusError)
+	if !ok {
+		statusErr = &cliout.StatusError{Status: err.Error(), StatusCode: 1, Cause: err}
+	}
+
+	msg := statusErr.Status
+	if statusErr.StatusCode == 1 && executedCmd != nil {
+		msg = fmt.Sprintf("%s\nSee '%s --help'", msg, executedCmd.CommandPath())
+	}
+	fmt.Fprintln(os.Stderr, msg)
+	return statusErr.StatusCode
 }
 
 func init() {
 	rootCmd.PersistentFlags().StringP("project", "p", ".", "Project directory")
 	rootCmd.PersistentFlags().StringP("config", "c", "", "Config file (default: .claudeshield.yaml)")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Verbose output")
+	rootCmd.PersistentFlags().String("output", "table", "Output format: table, json, or yaml")
+
+	rootCmd.SetFlagErrorFunc(flagErrorFunc)
+	rootCmd.SetUsageTemplate(usageTemplate)
 
 	rootCmd.AddCommand(startCmd)
 	rootCmd.AddCommand(stopCmd)
 	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(sessionCmd)
+	rootCmd.AddCommand(kubeCmd)
 	rootCmd.AddCommand(initConfigCmd)
 	rootCmd.AddCommand(auditCmd)
 	rootCmd.AddCommand(rollbackCmd)
-	rootCmd.AddCommand(agentCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(tuiCmd)
+
+	agentCmd.Annotations = map[string]string{"group": managementGroup}
+	secretsCmd.Annotations = map[string]string{"group": managementGroup}
+	rootCmd.AddCommand(agentCmd)
+	rootCmd.AddCommand(secretsCmd)
+	rootCmd.AddCommand(hubCmd)
+}
+
+// flagErrorFunc exits with code 125 on a flag parse error, matching docker's
+// convention of reserving 125 for "the claudeshield CLI itself failed" as
+// opposed to the invoked command failing.
+func flagErrorFunc(cmd *cobra.Command, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &cliout.StatusError{
+		Status:     fmt.Sprintf("%s\nSee '%s --help'", err, cmd.CommandPath()),
+		StatusCode: 125,
+	}
+}
+
+// outputFormat reads the --output persistent flag, defaulting to table.
+func outputFormat(cmd *cobra.Command) cliout.Format {
+	s, _ := cmd.Flags().GetString("output")
+	format, err := cliout.ParseFormat(s)
+	if err != nil {
+		return cliout.FormatTable
+	}
+	return format
 }
 
+const usageTemplate = `Usage:{{if .Runnable}}
+  {{.UseLine}}{{end}}{{if .HasAvailableSubCommands}}
+  {{.CommandPath}} [command]{{end}}{{if gt (len .Aliases) 0}}
+
+Aliases:
+  {{.NameAndAliases}}{{end}}{{if and .HasAvailableSubCommands (eq .Name "claudeshield")}}
+
+Management Commands:{{range .Commands}}{{if (and .IsAvailableCommand (eq (index .Annotations "group") "management"))}}
+  {{rpad .Name .NamePadding }} {{.Short}}{{end}}{{end}}
+
+Commands:{{range .Commands}}{{if (and .IsAvailableCommand (ne (index .Annotations "group") "management"))}}
+  {{rpad .Name .NamePadding }} {{.Short}}{{end}}{{end}}{{else if .HasAvailableSubCommands}}
+
+Available Commands:{{range .Commands}}{{if .IsAvailableCommand}}
+  {{rpad .Name .NamePadding }} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableLocalFlags}}
+
+Flags:
+{{.LocalFlags.FlagUsages | trimTrailingWhitespace}}{{end}}{{if .HasAvailableInheritedFlags}}
+
+Global Flags:
+{{.InheritedFlags.FlagUsages | trimTrailingWhitespace}}{{end}}{{if .HasHelpSubCommands}}
+
+Additional help topics:{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
+  {{rpad .CommandPath .CommandPathPadding}} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableSubCommands}}
+
+Use "{{.CommandPath}} [command] --help" for more information about a command.{{end}}
+`
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print ClaudeShield version",
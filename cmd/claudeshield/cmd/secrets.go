@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakazhanAlpamys/claudeshield/internal/secrets"
+	"github.com/spf13/cobra"
+)
+
+var secretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "Inspect secret providers",
+}
+
+var secretsDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "List discovered secret providers and their health",
+	Long:  "Lists built-in providers plus any claudeshield-secrets-* plugins discovered on $PATH or ~/.claudeshield/plugins, with an availability probe for each.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		registry := secrets.NewRegistry()
+		registry.DiscoverPlugins(nil)
+
+		fmt.Printf("%-20s %-12s %s\n", "PROVIDER", "HEALTH", "KIND")
+		fmt.Println("──────────────────────────────────────────────")
+
+		for _, p := range registry.Providers() {
+			health := "unavailable"
+			if p.Available() {
+				health = "available"
+			}
+			kind := "built-in"
+			if _, ok := p.(*secrets.ProcessProvider); ok {
+				kind = "plugin"
+			}
+			fmt.Printf("%-20s %-12s %s\n", p.Name(), health, kind)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	secretsCmd.AddCommand(secretsDoctorCmd)
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/MakazhanAlpamys/claudeshield/internal/audit"
+	"github.com/MakazhanAlpamys/claudeshield/internal/config"
+	"github.com/MakazhanAlpamys/claudeshield/internal/rollback"
+	"github.com/MakazhanAlpamys/claudeshield/internal/sandbox"
+	"github.com/MakazhanAlpamys/claudeshield/internal/secrets"
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var sessionCmd = &cobra.Command{
+	Use:   "session",
+	Short: "Operate on a single sandbox session",
+}
+
+var sessionCloneCmd = &cobra.Command{
+	Use:   "clone <session-id>",
+	Short: "Fork a running session with overridden resource limits",
+	Long:  "Creates a new container from a running session's inspected config, letting you try a risky change with different limits without losing the original session.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sourceID := args[0]
+		projectDir := getProjectDir(cmd)
+
+		cfg, err := config.Load(projectDir)
+		if err != nil {
+			return err
+		}
+
+		auditor, err := audit.NewLoggerWithConfig(cfg.Audit)
+		if err != nil {
+			return err
+		}
+		defer auditor.Close()
+
+		engine, err := sandbox.New(auditor, nil)
+		if err != nil {
+			return err
+		}
+		defer engine.Close()
+
+		sessions, err := engine.ListSessions(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		var source *types.Session
+		for _, s := range sessions {
+			if s.ID == sourceID {
+				source = s
+				break
+			}
+		}
+		if source == nil {
+			return fmt.Errorf("session %q not found", sourceID)
+		}
+
+		overrides, err := cloneOptionsFromFlags(cmd, engine, projectDir, cfg, source.ID)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("🧬 Cloning session %s...\n", sourceID)
+		clone, err := engine.CloneSession(cmd.Context(), source, overrides)
+		if err != nil {
+			return fmt.Errorf("cloning session: %w", err)
+		}
+
+		fmt.Printf("✅ Clone started: %s\n", clone.ID)
+		fmt.Printf("   Container: %s\n", clone.ContainerID[:12])
+		if overrides.Destroy {
+			fmt.Printf("   Source %s stopped\n", sourceID)
+		}
+		return nil
+	},
+}
+
+func cloneOptionsFromFlags(cmd *cobra.Command, engine *sandbox.Engine, projectDir string, cfg *types.ProjectConfig, sourceSessionID string) (sandbox.CloneOptions, error) {
+	name, _ := cmd.Flags().GetString("name")
+	memory, _ := cmd.Flags().GetString("memory")
+	cpus, _ := cmd.Flags().GetFloat64("cpus")
+	cpusetCPUs, _ := cmd.Flags().GetString("cpuset-cpus")
+	image, _ := cmd.Flags().GetString("image")
+	fromCheckpoint, _ := cmd.Flags().GetString("from-checkpoint")
+	destroy, _ := cmd.Flags().GetBool("destroy")
+
+	overrides := sandbox.CloneOptions{
+		Name:       name,
+		Memory:     memory,
+		CPUs:       cpus,
+		CPUSetCPUs: cpusetCPUs,
+		Image:      image,
+		Destroy:    destroy,
+	}
+
+	if cmd.Flags().Changed("network") {
+		network, _ := cmd.Flags().GetBool("network")
+		overrides.Network = &network
+	}
+
+	if fromCheckpoint != "" {
+		imageID, err := resolveCheckpointImage(engine, projectDir, sourceSessionID, fromCheckpoint)
+		if err != nil {
+			return sandbox.CloneOptions{}, fmt.Errorf("resolving --from-checkpoint: %w", err)
+		}
+		overrides.Image = imageID
+	}
+
+	registry := secrets.NewRegistry()
+	registry.DiscoverPlugins(nil)
+	if provider, err := registry.Get(cfg.Secrets.Provider); err == nil && provider.Available() {
+		if secretKeys := secrets.KeyNames(cfg.Secrets); len(secretKeys) > 0 {
+			if loaded, err := provider.Load(secretKeys); err == nil {
+				overrides.Secrets = loaded
+			}
+		}
+	}
+
+	return overrides, nil
+}
+
+// resolveCheckpointImage looks up checkpointID among the source session's
+// recorded checkpoints and returns its committed image. CRIU-mode
+// checkpoints (see rollback.Manager.CreateCRIUCheckpoint) have no image to
+// clone from — cloning from those isn't supported yet.
+func resolveCheckpointImage(engine *sandbox.Engine, projectDir, sourceSessionID, checkpointID string) (string, error) {
+	storagePath := filepath.Join(projectDir, ".claudeshield", "checkpoints.json")
+	mgr := rollback.New(engine.Client(), storagePath)
+
+	for _, cp := range mgr.ListCheckpoints(sourceSessionID) {
+		if cp.ID != checkpointID {
+			continue
+		}
+		if cp.CheckpointMode == types.CheckpointModeCRIU || cp.ImageID == "" {
+			return "", fmt.Errorf("checkpoint %s has no image to clone from (CRIU checkpoints aren't cloneable)", checkpointID)
+		}
+		return cp.ImageID, nil
+	}
+	return "", fmt.Errorf("checkpoint %s not found for session %s", checkpointID, sourceSessionID)
+}
+
+func init() {
+	sessionCloneCmd.Flags().String("name", "", "Name for the cloned agent/session")
+	sessionCloneCmd.Flags().String("memory", "", "Override memory limit (e.g. 4g)")
+	sessionCloneCmd.Flags().Float64("cpus", 0, "Override CPU limit")
+	sessionCloneCmd.Flags().String("cpuset-cpus", "", "Override CPU set, e.g. 0-3")
+	sessionCloneCmd.Flags().Bool("network", false, "Override network access (use --network=false to disable)")
+	sessionCloneCmd.Flags().String("image", "", "Use a different base image for the clone")
+	sessionCloneCmd.Flags().String("from-checkpoint", "", "Start the clone from this checkpoint's committed image instead of the source's current image")
+	sessionCloneCmd.Flags().Bool("destroy", false, "Stop and remove the source session once the clone is running")
+
+	sessionCmd.AddCommand(sessionCloneCmd)
+}
@@ -2,12 +2,16 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/MakazhanAlpamys/claudeshield/internal/audit"
+	"github.com/MakazhanAlpamys/claudeshield/internal/cliout"
 	"github.com/MakazhanAlpamys/claudeshield/internal/config"
 	"github.com/MakazhanAlpamys/claudeshield/internal/policy"
 	"github.com/MakazhanAlpamys/claudeshield/internal/sandbox"
 	"github.com/MakazhanAlpamys/claudeshield/internal/secrets"
+	"github.com/MakazhanAlpamys/claudeshield/internal/secrets/template"
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
 	"github.com/spf13/cobra"
 )
 
@@ -21,10 +25,10 @@ var startCmd = &cobra.Command{
 
 		cfg, err := config.Load(projectDir)
 		if err != nil {
-			return fmt.Errorf("loading config: %w", err)
+			return cliout.Wrap(cliout.ExitConfigInvalid, fmt.Errorf("loading config: %w", err))
 		}
 
-		auditor, err := audit.NewLogger(cfg.Audit.LogDir)
+		auditor, err := audit.NewLoggerWithConfig(cfg.Audit)
 		if err != nil {
 			return fmt.Errorf("creating auditor: %w", err)
 		}
@@ -32,15 +36,24 @@ var startCmd = &cobra.Command{
 
 		policyEngine := policy.New(cfg)
 
-		engine, err := sandbox.New(auditor, policyEngine)
+		var engine sandbox.Runtime
+		if cfg.Sandbox.Rootless {
+			if cfg.Runtime != "" && cfg.Runtime != "docker" && cfg.Runtime != "podman" {
+				return cliout.Errorf(cliout.ExitConfigInvalid, "rootless mode is only supported with the docker/podman runtime, got %q", cfg.Runtime)
+			}
+			engine, err = sandbox.NewRootless(auditor, policyEngine)
+		} else {
+			engine, err = sandbox.NewRuntime(cfg.Runtime, auditor, policyEngine)
+		}
 		if err != nil {
-			return err
+			return cliout.Wrap(cliout.ExitDaemonUnreachable, err)
 		}
 		defer engine.Close()
 
 		// Load secrets from configured provider
 		var loadedSecrets map[string]string
 		registry := secrets.NewRegistry()
+		registry.DiscoverPlugins(auditor)
 		provider, err := registry.Get(cfg.Secrets.Provider)
 		if err == nil && provider.Available() {
 			// Load secret keys from config options if specified
@@ -61,10 +74,23 @@ var startCmd = &cobra.Command{
 		fmt.Printf("   Agent:    %s\n", agentName)
 		fmt.Printf("   Network:  %v\n", cfg.Sandbox.Network)
 		fmt.Printf("   GVisor:   %v\n", cfg.Sandbox.UseGVisor)
+		fmt.Printf("   Rootless: %v\n", cfg.Sandbox.Rootless)
 		fmt.Printf("   Secrets:  %s\n", cfg.Secrets.Provider)
 		fmt.Printf("   Policy:   %d allow rules, %d block rules\n", len(cfg.Rules.Allow), len(cfg.Rules.Block))
 
-		session, err := engine.CreateSession(cmd.Context(), projectDir, cfg.Sandbox, agentName, loadedSecrets)
+		var session *types.Session
+		if len(cfg.Secrets.Templates) > 0 {
+			dockerEngine, ok := engine.(*sandbox.Engine)
+			if !ok {
+				return cliout.Errorf(cliout.ExitConfigInvalid, "secrets.templates requires the docker/podman runtime, got %q", cfg.Runtime)
+			}
+			if (provider == nil || !provider.Available()) && templatesReferenceSecrets(cfg.Secrets.Templates) {
+				return cliout.Errorf(cliout.ExitSecretProviderUnavailable, "secrets.templates references a \"secret:\" ref but provider %q is not configured/available", cfg.Secrets.Provider)
+			}
+			session, err = dockerEngine.CreateSessionWithTemplates(cmd.Context(), projectDir, cfg.Sandbox, agentName, loadedSecrets, cfg.Secrets.Templates, registry.NewTemplateResolver())
+		} else {
+			session, err = engine.CreateSession(cmd.Context(), projectDir, cfg.Sandbox, agentName, loadedSecrets)
+		}
 		if err != nil {
 			return fmt.Errorf("creating session: %w", err)
 		}
@@ -75,10 +101,35 @@ var startCmd = &cobra.Command{
 		fmt.Println("   Use 'claudeshield status' to see running sessions")
 		fmt.Println("   Use 'claudeshield audit' to view the audit log")
 
-		return nil
+		// Stay in the foreground and proxy host signals into the container
+		// so Ctrl-C stops the session instead of leaving it orphaned.
+		timeout := sandbox.DefaultStopTimeout
+		if cfg.Sandbox.StopTimeout > 0 {
+			timeout = time.Duration(cfg.Sandbox.StopTimeout) * time.Second
+		}
+		proxy := sandbox.NewSignalProxy(engine, session, timeout)
+		proxy.Start(cmd.Context())
+		fmt.Println("\n   Press Ctrl-C to stop the session")
+		proxy.Wait()
+
+		return &cliout.StatusError{Status: fmt.Sprintf("session %s stopped by signal", session.ID), StatusCode: cliout.ExitSignal}
 	},
 }
 
 func init() {
 	startCmd.Flags().StringP("agent", "a", "default", "Agent name")
 }
+
+// templatesReferenceSecrets reports whether any of templates actually
+// interpolates a {{ secret "..." }} ref, so startCmd can report a missing
+// provider as cliout.ExitSecretProviderUnavailable up front instead of
+// letting registry.NewTemplateResolver's resolver fail deep inside
+// template.Runner.Start with a generic error.
+func templatesReferenceSecrets(templates []types.SecretTemplate) bool {
+	for _, t := range templates {
+		if refs, err := template.SecretRefs(t.Src); err == nil && len(refs) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/MakazhanAlpamys/claudeshield/internal/audit"
 	"github.com/MakazhanAlpamys/claudeshield/internal/config"
 	"github.com/MakazhanAlpamys/claudeshield/internal/sandbox"
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
 	"github.com/spf13/cobra"
 )
 
@@ -13,51 +18,223 @@ var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show active sessions",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load(getProjectDir(cmd))
-		if err != nil {
-			return err
+		sessionID, _ := cmd.Flags().GetString("session")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		watch, _ := cmd.Flags().GetInt("watch")
+
+		if sessionID != "" {
+			return runSessionDetail(cmd, sessionID, jsonOutput)
+		}
+
+		if watch <= 0 {
+			return printStatus(cmd, jsonOutput)
+		}
+
+		ticker := time.NewTicker(time.Duration(watch) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			fmt.Print("\033[H\033[2J")
+			if err := printStatus(cmd, jsonOutput); err != nil {
+				return err
+			}
+			select {
+			case <-cmd.Context().Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+// printStatus lists every active session, in the same --json shape as
+// `claudeshield audit --json` uses for its own entries.
+func printStatus(cmd *cobra.Command, jsonOutput bool) error {
+	cfg, err := config.Load(getProjectDir(cmd))
+	if err != nil {
+		return err
+	}
+
+	auditor, err := audit.NewLoggerWithConfig(cfg.Audit)
+	if err != nil {
+		return err
+	}
+	defer auditor.Close()
+
+	engine, err := sandbox.NewRuntime(cfg.Runtime, auditor, nil)
+	if err != nil {
+		return err
+	}
+	defer engine.Close()
+
+	sessions, err := engine.ListSessions(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(sessions)
+	}
+
+	if len(sessions) == 0 {
+		fmt.Println("No active ClaudeShield sessions")
+		return nil
+	}
+
+	fmt.Printf("%-25s %-15s %-12s %-15s %s\n", "SESSION", "AGENT", "STATE", "CONTAINER", "PROJECT")
+	fmt.Println("─────────────────────────────────────────────────────────────────────────────────────")
+
+	for _, s := range sessions {
+		containerID := s.ContainerID
+		if len(containerID) > 12 {
+			containerID = containerID[:12]
+		}
+
+		fmt.Printf("%-25s %-15s %-12s %-15s %s\n",
+			s.ID,
+			s.AgentName,
+			s.State,
+			containerID,
+			s.ProjectDir,
+		)
+	}
+
+	return nil
+}
+
+// sessionDetail is the full inspection dump for `status --session <id>`,
+// covering the container itself, the policy it's running under, which
+// secrets were injected, and its recent audit history.
+type sessionDetail struct {
+	Session         *types.Session     `json:"session"`
+	CgroupParent    string             `json:"cgroup_parent,omitempty"`
+	Mounts          []string           `json:"mounts,omitempty"`
+	NetworkEnabled  bool               `json:"network_enabled"`
+	AllowedDevices  []string           `json:"allowed_devices,omitempty"`
+	ResolvedPolicy  types.RulesConfig  `json:"resolved_policy"`
+	InjectedSecrets []string           `json:"injected_secrets,omitempty"`
+	RecentAudit     []types.AuditEntry `json:"recent_audit,omitempty"`
+}
+
+const sessionDetailAuditEvents = 20
+
+func runSessionDetail(cmd *cobra.Command, sessionID string, jsonOutput bool) error {
+	projectDir := getProjectDir(cmd)
+	cfg, err := config.Load(projectDir)
+	if err != nil {
+		return err
+	}
+
+	auditor, err := audit.NewLoggerWithConfig(cfg.Audit)
+	if err != nil {
+		return err
+	}
+	defer auditor.Close()
+
+	engine, err := sandbox.NewRuntime(cfg.Runtime, auditor, nil)
+	if err != nil {
+		return err
+	}
+	defer engine.Close()
+
+	sessions, err := engine.ListSessions(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	var session *types.Session
+	for _, s := range sessions {
+		if s.ID == sessionID {
+			session = s
+			break
 		}
+	}
+	if session == nil {
+		return fmt.Errorf("no session found with ID %q", sessionID)
+	}
 
-		auditor, err := audit.NewLogger(cfg.Audit.LogDir)
-		if err != nil {
-			return err
+	detail := &sessionDetail{
+		Session:        session,
+		NetworkEnabled: cfg.Sandbox.Network,
+		AllowedDevices: cfg.Rules.Devices,
+		ResolvedPolicy: cfg.Rules,
+	}
+
+	if dockerEngine, ok := engine.(*sandbox.Engine); ok {
+		if inspect, err := dockerEngine.InspectSession(cmd.Context(), session); err == nil {
+			detail.CgroupParent = inspect.CgroupParent
+			detail.Mounts = inspect.Mounts
+			detail.InjectedSecrets = inspect.SecretKeys
 		}
-		defer auditor.Close()
+	}
 
-		engine, err := sandbox.New(auditor, nil)
-		if err != nil {
-			return err
+	logDir := cfg.Audit.LogDir
+	if !filepath.IsAbs(logDir) {
+		logDir = filepath.Join(projectDir, logDir)
+	}
+	if entries, err := audit.Query(logDir, audit.QueryOpts{SessionID: sessionID}); err == nil {
+		if len(entries) > sessionDetailAuditEvents {
+			entries = entries[len(entries)-sessionDetailAuditEvents:]
 		}
-		defer engine.Close()
+		detail.RecentAudit = entries
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(detail)
+	}
+
+	printSessionDetail(detail)
+	return nil
+}
+
+func printSessionDetail(d *sessionDetail) {
+	fmt.Printf("Session:        %s\n", d.Session.ID)
+	fmt.Printf("Agent:          %s\n", d.Session.AgentName)
+	fmt.Printf("State:          %s\n", d.Session.State)
+	fmt.Printf("Container:      %s\n", d.Session.ContainerID)
+	fmt.Printf("Project dir:    %s\n", d.Session.ProjectDir)
+	if d.CgroupParent != "" {
+		fmt.Printf("Cgroup parent:  %s\n", d.CgroupParent)
+	}
+	fmt.Printf("Network:        %t\n", d.NetworkEnabled)
 
-		sessions, err := engine.ListSessions(cmd.Context())
-		if err != nil {
-			return err
+	if len(d.Mounts) > 0 {
+		fmt.Println("\nMounts:")
+		for _, m := range d.Mounts {
+			fmt.Printf("  - %s\n", m)
 		}
+	}
 
-		if len(sessions) == 0 {
-			fmt.Println("No active ClaudeShield sessions")
-			return nil
+	if len(d.AllowedDevices) > 0 {
+		fmt.Println("\nAllowed devices:")
+		for _, dev := range d.AllowedDevices {
+			fmt.Printf("  - %s\n", dev)
 		}
+	}
 
-		fmt.Printf("%-25s %-15s %-12s %-15s %s\n", "SESSION", "AGENT", "STATE", "CONTAINER", "PROJECT")
-		fmt.Println("─────────────────────────────────────────────────────────────────────────────────────")
+	fmt.Printf("\nPolicy: %d allow rule(s), %d block rule(s)\n", len(d.ResolvedPolicy.Allow), len(d.ResolvedPolicy.Block))
 
-		for _, s := range sessions {
-			containerID := s.ContainerID
-			if len(containerID) > 12 {
-				containerID = containerID[:12]
-			}
+	if len(d.InjectedSecrets) > 0 {
+		fmt.Println("\nInjected secret keys:")
+		for _, key := range d.InjectedSecrets {
+			fmt.Printf("  - %s\n", key)
+		}
+	}
 
-			fmt.Printf("%-25s %-15s %-12s %-15s %s\n",
-				s.ID,
-				s.AgentName,
-				s.State,
-				containerID,
-				s.ProjectDir,
-			)
+	if len(d.RecentAudit) > 0 {
+		fmt.Printf("\nRecent audit events (last %d):\n", len(d.RecentAudit))
+		for _, e := range d.RecentAudit {
+			fmt.Printf("  [%s] %s %s %s\n", e.Timestamp.Format("15:04:05"), e.Action, e.EventType, e.Reason)
 		}
+	}
+}
 
-		return nil
-	},
+func init() {
+	statusCmd.Flags().Bool("json", false, "Output as JSON")
+	statusCmd.Flags().Int("watch", 0, "Repaint the session list every N seconds instead of exiting")
+	statusCmd.Flags().String("session", "", "Show full detail for a single session ID")
 }
@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/MakazhanAlpamys/claudeshield/internal/audit"
+	"github.com/MakazhanAlpamys/claudeshield/internal/cliout"
 	"github.com/MakazhanAlpamys/claudeshield/internal/config"
 	"github.com/MakazhanAlpamys/claudeshield/internal/sandbox"
 	"github.com/spf13/cobra"
@@ -15,19 +18,20 @@ var stopCmd = &cobra.Command{
 	Long:  "Stops and removes the Docker container for a ClaudeShield session.",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		all, _ := cmd.Flags().GetBool("all")
+		sig, _ := cmd.Flags().GetString("signal")
 
 		cfg, err := config.Load(getProjectDir(cmd))
 		if err != nil {
 			return err
 		}
 
-		auditor, err := audit.NewLogger(cfg.Audit.LogDir)
+		auditor, err := audit.NewLoggerWithConfig(cfg.Audit)
 		if err != nil {
 			return err
 		}
 		defer auditor.Close()
 
-		engine, err := sandbox.New(auditor, nil)
+		engine, err := sandbox.NewRuntime(cfg.Runtime, auditor, nil)
 		if err != nil {
 			return err
 		}
@@ -38,27 +42,51 @@ var stopCmd = &cobra.Command{
 			return err
 		}
 
-		if len(sessions) == 0 {
+		format := outputFormat(cmd)
+
+		if len(sessions) == 0 && format == cliout.FormatTable {
 			fmt.Println("No active sessions")
 			return nil
 		}
 
+		table := cliout.Table{Headers: []string{"SESSION", "AGENT", "RESULT"}}
 		for _, s := range sessions {
 			if !all && len(args) > 0 && s.ID != args[0] {
 				continue
 			}
 
-			if err := engine.StopSession(cmd.Context(), s); err != nil {
-				fmt.Printf("⚠️  Error stopping %s: %v\n", s.ID, err)
+			result := "stopped"
+			if sig != "" {
+				timeout := sandbox.DefaultStopTimeout
+				if cfg.Sandbox.StopTimeout > 0 {
+					timeout = time.Duration(cfg.Sandbox.StopTimeout) * time.Second
+				}
+				if err := engine.StopSessionWithSignal(cmd.Context(), s, sig, timeout); err != nil {
+					result = fmt.Sprintf("error: %v", err)
+				}
+			} else if err := engine.StopSession(cmd.Context(), s); err != nil {
+				result = fmt.Sprintf("error: %v", err)
+			}
+
+			if format == cliout.FormatTable {
+				if result == "stopped" {
+					fmt.Printf("🛑 Stopped: %s (%s)\n", s.ID, s.AgentName)
+				} else {
+					fmt.Printf("⚠️  Error stopping %s: %s\n", s.ID, result)
+				}
 				continue
 			}
-			fmt.Printf("🛑 Stopped: %s (%s)\n", s.ID, s.AgentName)
+			table.Rows = append(table.Rows, []string{s.ID, s.AgentName, result})
 		}
 
-		return nil
+		if format == cliout.FormatTable {
+			return nil
+		}
+		return cliout.Print(os.Stdout, format, table)
 	},
 }
 
 func init() {
 	stopCmd.Flags().Bool("all", false, "Stop all sessions")
+	stopCmd.Flags().String("signal", "", "Send this signal (e.g. SIGTERM, SIGHUP) and wait for a graceful exit before killing the container")
 }
@@ -5,6 +5,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/MakazhanAlpamys/claudeshield/internal/audit"
+	"github.com/MakazhanAlpamys/claudeshield/internal/cliout"
 	"github.com/MakazhanAlpamys/claudeshield/internal/config"
 	"github.com/MakazhanAlpamys/claudeshield/internal/sandbox"
 	"github.com/MakazhanAlpamys/claudeshield/internal/tui"
@@ -20,10 +21,10 @@ var tuiCmd = &cobra.Command{
 
 		cfg, err := config.Load(projectDir)
 		if err != nil {
-			return fmt.Errorf("loading config: %w", err)
+			return cliout.Wrap(cliout.ExitConfigInvalid, fmt.Errorf("loading config: %w", err))
 		}
 
-		auditor, err := audit.NewLogger(cfg.Audit.LogDir)
+		auditor, err := audit.NewLoggerWithConfig(cfg.Audit)
 		if err != nil {
 			return fmt.Errorf("creating auditor: %w", err)
 		}
@@ -31,7 +32,7 @@ var tuiCmd = &cobra.Command{
 
 		engine, err := sandbox.New(auditor, nil)
 		if err != nil {
-			return fmt.Errorf("connecting to Docker: %w", err)
+			return cliout.Wrap(cliout.ExitDaemonUnreachable, fmt.Errorf("connecting to Docker: %w", err))
 		}
 		defer engine.Close()
 
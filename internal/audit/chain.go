@@ -0,0 +1,125 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// hashJSON returns the hex SHA256 of entry's canonical (struct-order) JSON
+// encoding, used both as the "previous entry hash" input to the next link
+// and, via hashChainLink, as part of an entry's own Hash.
+func hashJSON(entry types.AuditEntry) string {
+	data, _ := json.Marshal(entry)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hashChainLink computes entry.Hash = SHA256(canonical_json(entry minus
+// Hash) || prevHash), binding the entry to both its own content and its
+// position in the chain.
+func hashChainLink(entry types.AuditEntry, prevHash string) string {
+	entry.Hash = ""
+	data, _ := json.Marshal(entry)
+	h := sha256.New()
+	h.Write(data)
+	h.Write([]byte(prevHash))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyResult reports the outcome of recomputing one audit log file's hash
+// chain.
+type VerifyResult struct {
+	File string
+	// EntriesOK is how many leading entries verified before Broken (or all
+	// of them, if the chain held end to end).
+	EntriesOK int
+	Broken    bool
+	// BrokenIndex is the 0-based index of the first entry whose PrevHash/Hash
+	// didn't match what was recomputed, or -1 if the chain wasn't broken.
+	BrokenIndex int
+	// ByteOffset is how far into File the broken entry ends, for an operator
+	// to jump straight to it.
+	ByteOffset int64
+	Reason     string
+	// TerminalHash is the last verified entry's Hash, the value --sign-with/
+	// --verify-with operate on. Empty if no entry verified.
+	TerminalHash string
+}
+
+// VerifyChain walks every audit-*.jsonl file under logDir — or, if sessionID
+// is non-empty, only files containing at least one entry for that session —
+// and recomputes each file's hash chain independently (a chain only ever
+// spans entries written by one Logger/file; see Logger.Log).
+func VerifyChain(logDir, sessionID string) ([]VerifyResult, error) {
+	files, err := filepath.Glob(filepath.Join(logDir, "audit-*.jsonl"))
+	if err != nil {
+		return nil, fmt.Errorf("listing audit logs: %w", err)
+	}
+
+	var results []VerifyResult
+	for _, f := range files {
+		if sessionID != "" {
+			entries, _ := readLogFile(f, sessionID)
+			if len(entries) == 0 {
+				continue
+			}
+		}
+		results = append(results, verifyFile(f))
+	}
+	return results, nil
+}
+
+func verifyFile(path string) VerifyResult {
+	result := VerifyResult{File: path, BrokenIndex: -1}
+
+	f, err := os.Open(path)
+	if err != nil {
+		result.Broken = true
+		result.Reason = err.Error()
+		return result
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(f)
+	var lastEntry *types.AuditEntry
+	index := 0
+
+	for dec.More() {
+		var entry types.AuditEntry
+		if err := dec.Decode(&entry); err != nil {
+			result.Broken = true
+			result.BrokenIndex = index
+			result.ByteOffset = dec.InputOffset()
+			result.Reason = fmt.Sprintf("malformed entry: %v", err)
+			return result
+		}
+
+		wantPrev := ""
+		if lastEntry != nil {
+			wantPrev = hashJSON(*lastEntry)
+		}
+		wantHash := hashChainLink(entry, wantPrev)
+
+		if entry.PrevHash != wantPrev || entry.Hash != wantHash {
+			result.Broken = true
+			result.BrokenIndex = index
+			result.ByteOffset = dec.InputOffset()
+			result.Reason = "hash chain mismatch"
+			return result
+		}
+
+		result.EntriesOK++
+		result.TerminalHash = entry.Hash
+		last := entry
+		lastEntry = &last
+		index++
+	}
+
+	return result
+}
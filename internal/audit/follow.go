@@ -0,0 +1,112 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// followPollInterval is how often Follow checks for newly appended entries
+// or a rotated-in log file.
+const followPollInterval = 500 * time.Millisecond
+
+// Follow streams entries matching opts from logDir's current (most recently
+// created) audit log file, tail-f style, until ctx is done: first those
+// already in the file, then each newly appended one.
+// It polls rather than using inotify/kqueue so it works the same across
+// every platform ClaudeShield targets. If a new `claudeshield start` rotates
+// onto a fresh audit-*.jsonl file, Follow notices and switches to it.
+func Follow(ctx context.Context, logDir string, opts QueryOpts, emit func(types.AuditEntry)) error {
+	currentFile := ""
+	var offset int64
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		latest, err := latestLogFile(logDir)
+		if err != nil {
+			return err
+		}
+		if latest == "" {
+			if !sleepOrDone(ctx, followPollInterval) {
+				return nil
+			}
+			continue
+		}
+		if latest != currentFile {
+			currentFile = latest
+			offset = 0
+		}
+
+		newOffset, err := tailFile(currentFile, offset, opts, emit)
+		if err != nil {
+			return err
+		}
+		offset = newOffset
+
+		if !sleepOrDone(ctx, followPollInterval) {
+			return nil
+		}
+	}
+}
+
+func sleepOrDone(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+func latestLogFile(logDir string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(logDir, "audit-*.jsonl"))
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return "", nil
+	}
+	sort.Strings(files)
+	return files[len(files)-1], nil
+}
+
+// tailFile decodes whatever complete JSONL entries have been appended to
+// path since offset, emitting the ones that pass opts, and returns the new
+// offset to resume from next time.
+func tailFile(path string, offset int64, opts QueryOpts, emit func(types.AuditEntry)) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return offset, nil
+		}
+		return offset, err
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return offset, err
+	}
+
+	dec := json.NewDecoder(f)
+	for dec.More() {
+		var entry types.AuditEntry
+		if err := dec.Decode(&entry); err != nil {
+			break
+		}
+		if matchesQuery(entry, opts) {
+			emit(entry)
+		}
+	}
+	return offset + dec.InputOffset(), nil
+}
@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// httpSink POSTs each entry as a single line of newline-delimited JSON to
+// url. A failed delivery is appended to a spool file under logDir instead
+// of being dropped; every subsequent Write first tries to flush the spool,
+// so entries queued during an outage eventually make it out once the
+// collector is reachable again.
+type httpSink struct {
+	url       string
+	client    *http.Client
+	spoolPath string
+	mu        sync.Mutex
+}
+
+const httpSinkMaxAttempts = 3
+
+func newHTTPSink(url, logDir string) (Sink, error) {
+	if err := os.MkdirAll(logDir, 0700); err != nil {
+		return nil, fmt.Errorf("creating audit log dir: %w", err)
+	}
+	return &httpSink{
+		url:       url,
+		client:    &http.Client{Timeout: 5 * time.Second},
+		spoolPath: filepath.Join(logDir, "http-spool.jsonl"),
+	}, nil
+}
+
+func (s *httpSink) Write(entry types.AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.flushSpoolLocked()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if s.postWithRetry(data) {
+		return nil
+	}
+	return s.spoolLocked(data)
+}
+
+func (s *httpSink) Close() error {
+	return nil
+}
+
+// postWithRetry makes up to httpSinkMaxAttempts attempts with a short
+// linear backoff, returning whether delivery succeeded.
+func (s *httpSink) postWithRetry(line []byte) bool {
+	for attempt := 1; attempt <= httpSinkMaxAttempts; attempt++ {
+		resp, err := s.client.Post(s.url, "application/x-ndjson", bytes.NewReader(append(line, '\n')))
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				return true
+			}
+		}
+		if attempt < httpSinkMaxAttempts {
+			time.Sleep(time.Duration(attempt) * 200 * time.Millisecond)
+		}
+	}
+	return false
+}
+
+func (s *httpSink) spoolLocked(line []byte) error {
+	f, err := os.OpenFile(s.spoolPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("spooling audit entry: %w", err)
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// flushSpoolLocked retries every spooled line; lines that still fail stay
+// in the spool for the next call. Called with s.mu already held.
+func (s *httpSink) flushSpoolLocked() {
+	data, err := os.ReadFile(s.spoolPath)
+	if err != nil || len(data) == 0 {
+		return
+	}
+
+	var remaining [][]byte
+	for _, line := range bytes.Split(bytes.TrimRight(data, "\n"), []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		if !s.postWithRetry(line) {
+			remaining = append(remaining, line)
+		}
+	}
+
+	if len(remaining) == 0 {
+		os.Remove(s.spoolPath)
+		return
+	}
+	os.WriteFile(s.spoolPath, append(bytes.Join(remaining, []byte("\n")), '\n'), 0600)
+}
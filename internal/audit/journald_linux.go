@@ -0,0 +1,38 @@
+//go:build linux
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// journaldSink forwards each entry's JSON to journald via systemd-cat,
+// tagged "claudeshield" so `journalctl -t claudeshield` surfaces it.
+type journaldSink struct{}
+
+func newJournaldSink() (Sink, error) {
+	if _, err := exec.LookPath("systemd-cat"); err != nil {
+		return nil, fmt.Errorf("systemd-cat not found (is systemd-journald installed?): %w", err)
+	}
+	return &journaldSink{}, nil
+}
+
+func (s *journaldSink) Write(entry types.AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command("systemd-cat", "-t", "claudeshield")
+	cmd.Stdin = bytes.NewReader(data)
+	return cmd.Run()
+}
+
+func (s *journaldSink) Close() error {
+	return nil
+}
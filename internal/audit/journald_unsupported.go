@@ -0,0 +1,9 @@
+//go:build !linux
+
+package audit
+
+import "fmt"
+
+func newJournaldSink() (Sink, error) {
+	return nil, fmt.Errorf("journald audit sink is only supported on Linux")
+}
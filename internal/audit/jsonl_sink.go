@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// jsonlSink appends one JSON object per line to a timestamped file under
+// logDir — the original, always-on audit.Logger behavior. Query reads back
+// whatever this sink has written.
+type jsonlSink struct {
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func newJSONLSink(logDir string) (*jsonlSink, error) {
+	if err := os.MkdirAll(logDir, 0700); err != nil {
+		return nil, fmt.Errorf("creating audit log dir: %w", err)
+	}
+
+	filename := fmt.Sprintf("audit-%s.jsonl", time.Now().Format("2006-01-02T15-04-05"))
+	path := filepath.Join(logDir, filename)
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+
+	return &jsonlSink{file: f, encoder: json.NewEncoder(f)}, nil
+}
+
+func (s *jsonlSink) Write(entry types.AuditEntry) error {
+	return s.encoder.Encode(entry)
+}
+
+func (s *jsonlSink) Close() error {
+	return s.file.Close()
+}
@@ -1,10 +1,13 @@
+// Package audit writes structured audit entries to a fan-out of sinks: the
+// local JSONL file is always on, with syslog, journald, and HTTP sinks
+// layered in via types.AuditConfig.Sinks so operators can forward policy
+// violations to a SIEM without a sidecar.
 package audit
 
 import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -12,36 +15,51 @@ import (
 	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
 )
 
-// Logger writes structured audit log entries to JSON files.
+// Logger fans out audit entries to every configured Sink.
 type Logger struct {
-	logDir  string
-	file    *os.File
-	encoder *json.Encoder
-	mu      sync.Mutex
+	logDir string
+	sinks  []Sink
+	mu     sync.Mutex
+	// lastEntry is the previously logged entry (with its own PrevHash/Hash
+	// already set), used to compute the next entry's PrevHash. nil for the
+	// first entry of the chain.
+	lastEntry *types.AuditEntry
 }
 
-// NewLogger creates a new audit logger that writes to the given directory.
+// NewLogger creates a Logger that writes only the local JSONL sink under
+// logDir — the original behavior, kept for callers (and tests) that don't
+// need additional sinks.
 func NewLogger(logDir string) (*Logger, error) {
-	if err := os.MkdirAll(logDir, 0700); err != nil {
-		return nil, fmt.Errorf("creating audit log dir: %w", err)
-	}
+	return NewLoggerWithConfig(types.AuditConfig{LogDir: logDir})
+}
 
-	filename := fmt.Sprintf("audit-%s.jsonl", time.Now().Format("2006-01-02T15-04-05"))
-	path := filepath.Join(logDir, filename)
+// NewLoggerWithConfig creates a Logger with the local JSONL sink plus
+// whatever additional sinks cfg.Sinks configures.
+func NewLoggerWithConfig(cfg types.AuditConfig) (*Logger, error) {
+	jsonl, err := newJSONLSink(cfg.LogDir)
+	if err != nil {
+		return nil, err
+	}
 
-	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	extra, err := buildSinks(cfg.LogDir, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("opening audit log: %w", err)
+		jsonl.Close()
+		return nil, err
 	}
 
-	return &Logger{
-		logDir:  logDir,
-		file:    f,
-		encoder: json.NewEncoder(f),
-	}, nil
+	sinks := make([]Sink, 0, len(extra)+1)
+	sinks = append(sinks, jsonl)
+	sinks = append(sinks, extra...)
+
+	return &Logger{logDir: cfg.LogDir, sinks: sinks}, nil
 }
 
-// Log writes a single audit entry.
+// Log writes a single audit entry to every sink. A sink write failure is
+// swallowed — audit logging must never block or crash the caller.
+//
+// Before writing, Log chains entry to the previous one (PrevHash/Hash) so a
+// compromised agent can't rewrite history without `claudeshield audit
+// verify` noticing the break. See VerifyChain.
 func (l *Logger) Log(entry types.AuditEntry) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -50,35 +68,43 @@ func (l *Logger) Log(entry types.AuditEntry) {
 		entry.Timestamp = time.Now()
 	}
 
-	_ = l.encoder.Encode(entry)
+	prevHash := ""
+	if l.lastEntry != nil {
+		prevHash = hashJSON(*l.lastEntry)
+	}
+	entry.PrevHash = prevHash
+	entry.Hash = hashChainLink(entry, prevHash)
+
+	for _, sink := range l.sinks {
+		_ = sink.Write(entry)
+	}
+
+	last := entry
+	l.lastEntry = &last
 }
 
-// Close closes the log file.
+// Close closes every sink, returning one error that joins the messages of
+// every sink that failed to close.
 func (l *Logger) Close() error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	return l.file.Close()
-}
-
-// ReadSession reads all audit entries for a specific session.
-func ReadSession(logDir, sessionID string) ([]types.AuditEntry, error) {
-	files, err := filepath.Glob(filepath.Join(logDir, "audit-*.jsonl"))
-	if err != nil {
-		return nil, fmt.Errorf("listing audit logs: %w", err)
-	}
 
-	var entries []types.AuditEntry
-	for _, f := range files {
-		fileEntries, err := readLogFile(f, sessionID)
-		if err != nil {
-			continue
+	var errs []string
+	for _, sink := range l.sinks {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, err.Error())
 		}
-		entries = append(entries, fileEntries...)
 	}
-
-	return entries, nil
+	if len(errs) > 0 {
+		return fmt.Errorf("closing audit sinks: %s", strings.Join(errs, "; "))
+	}
+	return nil
 }
 
+// readLogFile reads every entry in path matching sessionID (or every entry,
+// if sessionID is empty) — a lightweight, non-streaming helper for the
+// handful of callers (VerifyChain's per-file session filter) that just need
+// "does this file mention this session", not Query's full predicate set.
 func readLogFile(path, sessionID string) ([]types.AuditEntry, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// QueryOpts narrows a Query down to the entries an investigation actually
+// cares about, so a large log directory doesn't have to be fully decoded
+// and held in memory just to answer "what did agent X run since 2h ago".
+// A zero-value field means "don't filter on this".
+type QueryOpts struct {
+	SessionID string
+	Since     time.Time
+	Until     time.Time
+	Events    []string
+	Actions   []types.PolicyAction
+	Agent     string
+	// Grep matches against entry.Command, nil means no filter.
+	Grep *regexp.Regexp
+	// Last caps the result to the most recent N matching entries. 0 means
+	// unlimited.
+	Last int
+}
+
+// Query reads matching audit entries across every audit-*.jsonl file under
+// logDir, newest file first, stopping once opts.Last matching entries have
+// been collected so a directory of many days' logs doesn't need every file
+// decoded just to answer a bounded query (each individual file is still
+// decoded in full — see queryFile). The result is always in chronological
+// order, oldest match first.
+func Query(logDir string, opts QueryOpts) ([]types.AuditEntry, error) {
+	files, err := filepath.Glob(filepath.Join(logDir, "audit-*.jsonl"))
+	if err != nil {
+		return nil, fmt.Errorf("listing audit logs: %w", err)
+	}
+	// Filenames are "audit-<timestamp>.jsonl", so lexicographic order is
+	// chronological; reverse it to walk newest first.
+	sort.Sort(sort.Reverse(sort.StringSlice(files)))
+
+	var matched []types.AuditEntry
+	total := 0
+	for _, f := range files {
+		entries, err := queryFile(f, opts)
+		if err != nil {
+			continue
+		}
+		matched = append(entries, matched...)
+		total += len(entries)
+		if opts.Last > 0 && total >= opts.Last {
+			break
+		}
+	}
+
+	if opts.Last > 0 && len(matched) > opts.Last {
+		matched = matched[len(matched)-opts.Last:]
+	}
+	return matched, nil
+}
+
+// queryFile stream-decodes path one JSONL entry at a time, rather than
+// reading the whole file into memory first, keeping only entries that pass
+// opts. It always decodes the full file — entries within a file are in
+// chronological (oldest-first) order, so stopping partway through would
+// keep the oldest matches instead of the newest ones opts.Last actually
+// wants. The early exit that skips scanning whole files lives one level up,
+// in Query's newest-file-first loop.
+func queryFile(path string, opts QueryOpts) ([]types.AuditEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []types.AuditEntry
+	dec := json.NewDecoder(f)
+	for dec.More() {
+		var entry types.AuditEntry
+		if err := dec.Decode(&entry); err != nil {
+			continue
+		}
+		if matchesQuery(entry, opts) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func matchesQuery(entry types.AuditEntry, opts QueryOpts) bool {
+	if opts.SessionID != "" && entry.SessionID != opts.SessionID {
+		return false
+	}
+	if !opts.Since.IsZero() && entry.Timestamp.Before(opts.Since) {
+		return false
+	}
+	if !opts.Until.IsZero() && entry.Timestamp.After(opts.Until) {
+		return false
+	}
+	if len(opts.Events) > 0 && !eventMatches(opts.Events, entry.EventType) {
+		return false
+	}
+	if len(opts.Actions) > 0 && !containsAction(opts.Actions, entry.Action) {
+		return false
+	}
+	if opts.Agent != "" && entry.AgentName != opts.Agent {
+		return false
+	}
+	if opts.Grep != nil && !opts.Grep.MatchString(entry.Command) {
+		return false
+	}
+	return true
+}
+
+// eventMatches reports whether eventType matches one of the filter terms,
+// by substring so a short filter like "exec" or "session" matches this
+// repo's underscore-joined event names ("command_exec", "session_created",
+// "session_stopped", ...) without requiring the exact name.
+func eventMatches(filters []string, eventType string) bool {
+	for _, f := range filters {
+		if strings.Contains(eventType, f) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAction(list []types.PolicyAction, a types.PolicyAction) bool {
+	for _, v := range list {
+		if v == a {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SignTerminalHash signs hash (a VerifyResult.TerminalHash) with the
+// ed25519 private key stored as a single base64-encoded line in keyPath,
+// writing the base64-encoded signature to logPath+".sig".
+func SignTerminalHash(logPath, keyPath, hash string) error {
+	key, err := readPrivateKey(keyPath)
+	if err != nil {
+		return err
+	}
+	sig := ed25519.Sign(key, []byte(hash))
+	return os.WriteFile(logPath+".sig", []byte(base64.StdEncoding.EncodeToString(sig)+"\n"), 0600)
+}
+
+// VerifyTerminalHash checks logPath+".sig" against hash using the ed25519
+// public key stored as a single base64-encoded line in pubkeyPath.
+func VerifyTerminalHash(logPath, pubkeyPath, hash string) error {
+	pub, err := readPublicKey(pubkeyPath)
+	if err != nil {
+		return err
+	}
+
+	sigB64, err := os.ReadFile(logPath + ".sig")
+	if err != nil {
+		return fmt.Errorf("reading signature: %w", err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sigB64)))
+	if err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+
+	if !ed25519.Verify(pub, []byte(hash), sig) {
+		return fmt.Errorf("signature does not match terminal hash")
+	}
+	return nil
+}
+
+func readPrivateKey(path string) (ed25519.PrivateKey, error) {
+	raw, err := readKeyFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading private key: %w", err)
+	}
+	if len(raw) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("private key is %d bytes, want %d", len(raw), ed25519.PrivateKeySize)
+	}
+	return ed25519.PrivateKey(raw), nil
+}
+
+func readPublicKey(path string) (ed25519.PublicKey, error) {
+	raw, err := readKeyFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading public key: %w", err)
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("public key is %d bytes, want %d", len(raw), ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+func readKeyFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+}
@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"fmt"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// Sink is one destination audit entries are written to. Write must be safe
+// to call from the Logger's single mutex-guarded path — implementations
+// don't need their own locking unless they're also used standalone.
+type Sink interface {
+	Write(entry types.AuditEntry) error
+	Close() error
+}
+
+// buildSinks constructs the additional sinks configured in cfg, in order.
+// The caller is responsible for always including the local JSONL sink.
+func buildSinks(logDir string, cfg types.AuditConfig) ([]Sink, error) {
+	sinks := make([]Sink, 0, len(cfg.Sinks))
+	for _, sc := range cfg.Sinks {
+		var sink Sink
+		var err error
+
+		switch sc.Type {
+		case "syslog":
+			sink, err = newSyslogSink(sc.Address)
+		case "journald":
+			sink, err = newJournaldSink()
+		case "http":
+			url := sc.URL
+			if url == "" {
+				url = cfg.CloudURL
+			}
+			if url == "" {
+				err = fmt.Errorf("http sink requires a url (or audit.cloud_url)")
+				break
+			}
+			sink, err = newHTTPSink(url, logDir)
+		default:
+			err = fmt.Errorf("unknown audit sink type %q", sc.Type)
+		}
+
+		if err != nil {
+			for _, s := range sinks {
+				s.Close()
+			}
+			return nil, fmt.Errorf("configuring %q sink: %w", sc.Type, err)
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
@@ -0,0 +1,54 @@
+//go:build !windows
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+	"net/url"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// syslogSink forwards each entry as a single JSON line at LOG_INFO, tagged
+// "claudeshield", so a SIEM's syslog collector can pick up policy
+// violations without a dedicated agent.
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+// newSyslogSink dials addr ("" for the local syslog daemon, or
+// "udp://host:514" / "tcp://host:514" for a remote one).
+func newSyslogSink(addr string) (Sink, error) {
+	if addr == "" {
+		w, err := syslog.New(syslog.LOG_INFO, "claudeshield")
+		if err != nil {
+			return nil, fmt.Errorf("connecting to local syslog: %w", err)
+		}
+		return &syslogSink{writer: w}, nil
+	}
+
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, fmt.Errorf("parsing syslog address %q: %w", addr, err)
+	}
+
+	w, err := syslog.Dial(u.Scheme, u.Host, syslog.LOG_INFO, "claudeshield")
+	if err != nil {
+		return nil, fmt.Errorf("dialing syslog at %s: %w", addr, err)
+	}
+	return &syslogSink{writer: w}, nil
+}
+
+func (s *syslogSink) Write(entry types.AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return s.writer.Info(string(data))
+}
+
+func (s *syslogSink) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package audit
+
+import "fmt"
+
+func newSyslogSink(addr string) (Sink, error) {
+	return nil, fmt.Errorf("syslog audit sink is not supported on this platform")
+}
@@ -0,0 +1,99 @@
+// Package cliout centralizes how subcommands render their results so the
+// same data can be read by a human (the current emoji-decorated text) or by
+// a script (--output=json|yaml), instead of every command fmt.Printf-ing its
+// own ad-hoc layout.
+package cliout
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format selects how a table of records is rendered.
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+)
+
+// ParseFormat validates a --output flag value.
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case FormatTable, FormatJSON, FormatYAML:
+		return Format(s), nil
+	default:
+		return "", fmt.Errorf("unknown output format %q (want table, json, or yaml)", s)
+	}
+}
+
+// Table is a set of named columns and the rows that fill them, in the order
+// callers want them printed.
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// Print renders t in the requested format. Table output reproduces the
+// existing fixed-width column layout; JSON and YAML emit one object per row,
+// keyed by a lowercased header, for consumption by scripts and CI.
+func Print(w io.Writer, format Format, t Table) error {
+	switch format {
+	case FormatJSON:
+		return json.NewEncoder(w).Encode(t.records())
+	case FormatYAML:
+		return yaml.NewEncoder(w).Encode(t.records())
+	default:
+		return printTable(w, t)
+	}
+}
+
+func (t Table) records() []map[string]string {
+	records := make([]map[string]string, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		record := make(map[string]string, len(t.Headers))
+		for i, h := range t.Headers {
+			if i < len(row) {
+				record[strings.ToLower(h)] = row[i]
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func printTable(w io.Writer, t Table) error {
+	widths := make([]int, len(t.Headers))
+	for i, h := range t.Headers {
+		widths[i] = len(h)
+	}
+	for _, row := range t.Rows {
+		for i, cell := range row {
+			if i < len(widths) && len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	printRow(w, t.Headers, widths)
+	for _, row := range t.Rows {
+		printRow(w, row, widths)
+	}
+	return nil
+}
+
+func printRow(w io.Writer, cells []string, widths []int) {
+	for i, width := range widths {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		fmt.Fprintf(w, "%-*s ", width+2, cell)
+	}
+	fmt.Fprintln(w)
+}
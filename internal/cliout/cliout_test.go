@@ -0,0 +1,60 @@
+package cliout
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	if _, err := ParseFormat("json"); err != nil {
+		t.Errorf("json should be a valid format: %v", err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("xml should not be a valid format")
+	}
+}
+
+func TestPrint_Table(t *testing.T) {
+	var buf bytes.Buffer
+	table := Table{
+		Headers: []string{"AGENT", "STATE"},
+		Rows:    [][]string{{"alice", "running"}},
+	}
+	if err := Print(&buf, FormatTable, table); err != nil {
+		t.Fatalf("Print: %v", err)
+	}
+	if !strings.Contains(buf.String(), "alice") || !strings.Contains(buf.String(), "AGENT") {
+		t.Errorf("table output missing expected content: %q", buf.String())
+	}
+}
+
+func TestPrint_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	table := Table{
+		Headers: []string{"AGENT", "STATE"},
+		Rows:    [][]string{{"alice", "running"}},
+	}
+	if err := Print(&buf, FormatJSON, table); err != nil {
+		t.Fatalf("Print: %v", err)
+	}
+
+	var records []map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil {
+		t.Fatalf("decoding JSON output: %v", err)
+	}
+	if len(records) != 1 || records[0]["agent"] != "alice" {
+		t.Errorf("unexpected JSON records: %+v", records)
+	}
+}
+
+func TestStatusError_Error(t *testing.T) {
+	err := Errorf(125, "bad flag %q", "--foo")
+	if err.Error() != `bad flag "--foo"` {
+		t.Errorf("unexpected message: %s", err.Error())
+	}
+	if err.StatusCode != 125 {
+		t.Errorf("StatusCode = %d, want 125", err.StatusCode)
+	}
+}
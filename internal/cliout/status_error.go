@@ -0,0 +1,55 @@
+package cliout
+
+import "fmt"
+
+// Exit codes a StatusError can carry, mirroring Docker CLI's convention of
+// giving each class of CLI failure its own code so automation can branch on
+// the cause instead of parsing stderr.
+const (
+	// ExitDaemonUnreachable means the configured container runtime (Docker,
+	// containerd, Podman) could not be reached.
+	ExitDaemonUnreachable = 125
+	// ExitPolicyDenied means a policy.Engine rule blocked the requested
+	// command, file access, or device.
+	ExitPolicyDenied = 126
+	// ExitConfigInvalid means .claudeshield.yaml (or a flag derived from it)
+	// failed to load or doesn't describe a runnable sandbox.
+	ExitConfigInvalid = 127
+	// ExitSecretProviderUnavailable means a configured secrets provider (or
+	// a secret template referencing one) couldn't be reached.
+	ExitSecretProviderUnavailable = 128
+	// ExitSignal means the command was ended by a proxied host signal
+	// (see sandbox.SignalProxy) rather than failing outright.
+	ExitSignal = 130
+)
+
+// StatusError carries a human-readable status plus the process exit code it
+// should produce. RunE funcs that need a non-default exit code (for example
+// a policy denial vs. a usage error) should return one of these instead of a
+// bare error so main can translate it without re-inspecting error strings.
+type StatusError struct {
+	Status     string
+	StatusCode int
+	// Cause is the underlying error, if any, that StatusError wraps —
+	// callers can errors.Is/As through it same as fmt.Errorf's %w.
+	Cause error
+}
+
+func (e *StatusError) Error() string {
+	return e.Status
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Cause
+}
+
+// Errorf builds a StatusError from a format string, mirroring fmt.Errorf.
+func Errorf(code int, format string, args ...interface{}) *StatusError {
+	return &StatusError{Status: fmt.Sprintf(format, args...), StatusCode: code}
+}
+
+// Wrap attaches an exit code to an existing error, keeping it reachable via
+// errors.Is/As through Unwrap.
+func Wrap(code int, cause error) *StatusError {
+	return &StatusError{Status: cause.Error(), StatusCode: code, Cause: cause}
+}
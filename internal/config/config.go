@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/MakazhanAlpamys/claudeshield/internal/hub"
 	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
 	"gopkg.in/yaml.v3"
 )
@@ -17,6 +18,7 @@ const (
 // DefaultConfig returns sensible defaults for a new project.
 func DefaultConfig() *types.ProjectConfig {
 	return &types.ProjectConfig{
+		Runtime: "docker",
 		Sandbox: types.SandboxConfig{
 			Mount:       ".:/workspace:rw",
 			Network:     false,
@@ -86,6 +88,16 @@ func Load(projectDir string) (*types.ProjectConfig, error) {
 		return nil, fmt.Errorf("parsing config %s: %w", configPath, err)
 	}
 
+	if len(cfg.Bundles) > 0 {
+		hubDir, err := GlobalConfigDir()
+		if err != nil {
+			return nil, err
+		}
+		if err := hub.New(filepath.Join(hubDir, hub.CacheDirName)).MergeInto(cfg); err != nil {
+			return nil, fmt.Errorf("merging hub bundles: %w", err)
+		}
+	}
+
 	return cfg, nil
 }
 
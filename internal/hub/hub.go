@@ -0,0 +1,332 @@
+// Package hub pulls curated, versioned policy rule bundles from a remote
+// index and caches them locally, CrowdSec-hub style, so a project can pin
+// community-maintained baselines ("python-dev", "node-safe", "cve-2024-...")
+// instead of hand-writing every allow/block rule.
+package hub
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultIndexURL is the default remote index of available bundles.
+const DefaultIndexURL = "https://hub.claudeshield.dev/index.yaml"
+
+// CacheDirName is the subdirectory of the global config dir where installed
+// bundles are cached, e.g. ~/.claudeshield/hub/python-dev.yaml.
+const CacheDirName = "hub"
+
+// BundleMeta describes a bundle without its rules, as returned by List.
+type BundleMeta struct {
+	Name        string   `yaml:"name"`
+	Version     string   `yaml:"version"`
+	Author      string   `yaml:"author"`
+	Description string   `yaml:"description"`
+	MinVersion  string   `yaml:"min_version,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+}
+
+// Bundle is a signed rule bundle: the same allow/block schema as a project's
+// own RulesConfig, plus the metadata that lets it be discovered and pinned.
+type Bundle struct {
+	BundleMeta `yaml:",inline"`
+	Rules      types.RulesConfig `yaml:"rules"`
+	// Signature is a base64-encoded ed25519 signature over the bundle's
+	// canonical YAML encoding with this field cleared, checked against
+	// Hub.trustedKeys by verifyBundle. See TrustedKeysFileName.
+	Signature string `yaml:"signature,omitempty"`
+}
+
+// TrustedKeysFileName is the file, a sibling of the hub cache dir, holding
+// one base64-encoded ed25519 public key per line (blank lines and "#"
+// comments ignored) that bundle signatures are checked against. Populated
+// via `claudeshield hub trust <pubkey>`.
+const TrustedKeysFileName = "trusted_keys"
+
+// Hub manages the local bundle cache and talks to the remote index.
+type Hub struct {
+	cacheDir    string
+	indexURL    string
+	client      *http.Client
+	trustedKeys []ed25519.PublicKey
+}
+
+// New creates a Hub caching bundles under cacheDir (typically
+// <GlobalConfigDir>/hub). Trusted signing keys are loaded from
+// TrustedKeysFileName next to cacheDir, if present; a missing file just
+// means no bundle will verify yet, not an error.
+func New(cacheDir string) *Hub {
+	h := &Hub{
+		cacheDir: cacheDir,
+		indexURL: DefaultIndexURL,
+		client:   http.DefaultClient,
+	}
+	h.trustedKeys, _ = loadTrustedKeys(h.trustedKeysPath())
+	return h
+}
+
+// TrustedKeysPath returns the file Hub reads/appends trusted signing keys
+// to, for `claudeshield hub trust` to manage.
+func (h *Hub) TrustedKeysPath() string {
+	return h.trustedKeysPath()
+}
+
+func (h *Hub) trustedKeysPath() string {
+	return filepath.Join(filepath.Dir(h.cacheDir), TrustedKeysFileName)
+}
+
+// List fetches the remote index and returns the available bundles.
+func (h *Hub) List(ctx context.Context) ([]BundleMeta, error) {
+	data, err := h.fetch(ctx, h.indexURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetching hub index: %w", err)
+	}
+
+	var index []BundleMeta
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("parsing hub index: %w", err)
+	}
+	return index, nil
+}
+
+// Install downloads and caches the named bundle, overwriting any existing
+// cached copy — this is also how Update is implemented.
+func (h *Hub) Install(ctx context.Context, name string) (*Bundle, error) {
+	data, err := h.fetch(ctx, h.bundleURL(name))
+	if err != nil {
+		return nil, fmt.Errorf("fetching bundle %q: %w", name, err)
+	}
+
+	var bundle Bundle
+	if err := yaml.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("parsing bundle %q: %w", name, err)
+	}
+	if err := h.verifyBundle(&bundle); err != nil {
+		return nil, fmt.Errorf("bundle %q failed signature verification: %w", name, err)
+	}
+
+	if err := os.MkdirAll(h.cacheDir, 0700); err != nil {
+		return nil, fmt.Errorf("creating hub cache dir: %w", err)
+	}
+	if err := os.WriteFile(h.cachePath(name), data, 0600); err != nil {
+		return nil, fmt.Errorf("caching bundle %q: %w", name, err)
+	}
+
+	return &bundle, nil
+}
+
+// Update re-fetches the named bundle's latest version.
+func (h *Hub) Update(ctx context.Context, name string) (*Bundle, error) {
+	return h.Install(ctx, name)
+}
+
+// Remove deletes the named bundle from the local cache.
+func (h *Hub) Remove(name string) error {
+	if err := os.Remove(h.cachePath(name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing bundle %q: %w", name, err)
+	}
+	return nil
+}
+
+// Get reads the named bundle from the local cache. It does not touch the
+// network — a bundle must already be installed.
+func (h *Hub) Get(name string) (*Bundle, error) {
+	data, err := os.ReadFile(h.cachePath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("bundle %q is not installed, run 'claudeshield hub install %s'", name, name)
+		}
+		return nil, fmt.Errorf("reading cached bundle %q: %w", name, err)
+	}
+
+	var bundle Bundle
+	if err := yaml.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("parsing cached bundle %q: %w", name, err)
+	}
+	return &bundle, nil
+}
+
+// Installed lists every bundle currently cached locally.
+func (h *Hub) Installed() ([]Bundle, error) {
+	entries, err := os.ReadDir(h.cacheDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading hub cache dir: %w", err)
+	}
+
+	var bundles []Bundle
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".yaml") {
+			continue
+		}
+		bundle, err := h.Get(strings.TrimSuffix(entry.Name(), ".yaml"))
+		if err != nil {
+			return nil, err
+		}
+		bundles = append(bundles, *bundle)
+	}
+	return bundles, nil
+}
+
+// MergeInto appends every bundle pinned in cfg.Bundles onto cfg.Rules,
+// allow rules first then block rules, bundle-by-bundle in pin order. Each
+// pinned name must already be installed in the cache.
+func (h *Hub) MergeInto(cfg *types.ProjectConfig) error {
+	for _, ref := range cfg.Bundles {
+		name, _ := splitBundleRef(ref)
+		bundle, err := h.Get(name)
+		if err != nil {
+			return err
+		}
+		cfg.Rules.Allow = append(cfg.Rules.Allow, bundle.Rules.Allow...)
+		cfg.Rules.Block = append(cfg.Rules.Block, bundle.Rules.Block...)
+		cfg.Rules.Devices = append(cfg.Rules.Devices, bundle.Rules.Devices...)
+	}
+	return nil
+}
+
+func (h *Hub) cachePath(name string) string {
+	return filepath.Join(h.cacheDir, name+".yaml")
+}
+
+func (h *Hub) bundleURL(name string) string {
+	return strings.TrimSuffix(h.indexURL, "index.yaml") + name + ".yaml"
+}
+
+func (h *Hub) fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// verifyBundle checks b.Signature against every key in h.trustedKeys,
+// refusing unsigned bundles and bundles with no configured trust anchors
+// outright rather than treating them as trusted-by-default.
+func (h *Hub) verifyBundle(b *Bundle) error {
+	if b.Signature == "" {
+		return fmt.Errorf("bundle is unsigned")
+	}
+	if len(h.trustedKeys) == 0 {
+		return fmt.Errorf("no trusted signing keys configured, run 'claudeshield hub trust <pubkey>'")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(b.Signature)
+	if err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+
+	payload, err := canonicalBundleBytes(*b)
+	if err != nil {
+		return fmt.Errorf("canonicalizing bundle: %w", err)
+	}
+
+	for _, key := range h.trustedKeys {
+		if ed25519.Verify(key, payload, sig) {
+			return nil
+		}
+	}
+	return fmt.Errorf("signature does not match any trusted key")
+}
+
+// canonicalBundleBytes re-serializes b with Signature cleared, so signing
+// and verifying always hash the same bytes regardless of what (if
+// anything) the wire copy's signature field held.
+func canonicalBundleBytes(b Bundle) ([]byte, error) {
+	b.Signature = ""
+	return yaml.Marshal(b)
+}
+
+// loadTrustedKeys reads one base64-encoded ed25519 public key per line from
+// path, skipping blank lines and "#" comments. A missing file yields an
+// empty, non-error result — callers treat "no trusted keys" as "nothing
+// verifies yet", not a startup failure.
+func loadTrustedKeys(path string) ([]ed25519.PublicKey, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var keys []ed25519.PublicKey
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		raw, err := base64.StdEncoding.DecodeString(line)
+		if err != nil {
+			return nil, fmt.Errorf("parsing trusted key %q: %w", line, err)
+		}
+		if len(raw) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("trusted key %q is %d bytes, want %d", line, len(raw), ed25519.PublicKeySize)
+		}
+		keys = append(keys, ed25519.PublicKey(raw))
+	}
+	return keys, scanner.Err()
+}
+
+// TrustKey appends pubkeyB64 (a base64-encoded ed25519 public key) to this
+// Hub's trusted keys file, validating it decodes to the right size before
+// writing, and reloads h.trustedKeys so it takes effect immediately.
+func (h *Hub) TrustKey(pubkeyB64 string) error {
+	raw, err := base64.StdEncoding.DecodeString(pubkeyB64)
+	if err != nil {
+		return fmt.Errorf("decoding public key: %w", err)
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return fmt.Errorf("public key is %d bytes, want %d", len(raw), ed25519.PublicKeySize)
+	}
+
+	path := h.trustedKeysPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating hub config dir: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("opening trusted keys file: %w", err)
+	}
+	defer f.Close()
+	if _, err := fmt.Fprintln(f, pubkeyB64); err != nil {
+		return fmt.Errorf("writing trusted key: %w", err)
+	}
+
+	h.trustedKeys = append(h.trustedKeys, ed25519.PublicKey(raw))
+	return nil
+}
+
+// splitBundleRef splits a "name@version" pin into its parts; a bare name
+// pins whatever version is currently installed.
+func splitBundleRef(ref string) (name, version string) {
+	if i := strings.Index(ref, "@"); i >= 0 {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, ""
+}
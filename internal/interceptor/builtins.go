@@ -0,0 +1,126 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MakazhanAlpamys/claudeshield/internal/audit"
+	"github.com/MakazhanAlpamys/claudeshield/internal/policy"
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// Recovery catches panics from the wrapped handler (e.g. a panicking
+// git/docker shell-out) and converts them into an error plus an audit
+// entry, instead of crashing the process and leaving a worktree or
+// container in an inconsistent state.
+func Recovery(auditor *audit.Logger) Interceptor {
+	return func(ctx context.Context, op Op, next Handler) (res Result, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic in %s: %v", op, r)
+				if auditor != nil {
+					auditor.Log(types.AuditEntry{
+						EventType: "panic_recovered",
+						Command:   string(op),
+						Action:    types.ActionBlock,
+						Reason:    err.Error(),
+					})
+				}
+			}
+		}()
+		return next(ctx)
+	}
+}
+
+// Audit emits a single audit entry per operation with a correlation ID,
+// so concurrent agents' events can be told apart in the JSONL stream.
+func Audit(auditor *audit.Logger) Interceptor {
+	return func(ctx context.Context, op Op, next Handler) (Result, error) {
+		if auditor == nil {
+			return next(ctx)
+		}
+
+		res, err := next(ctx)
+
+		entry := types.AuditEntry{
+			EventType: string(op),
+			Command:   fmt.Sprintf("%s-%d", op, time.Now().UnixNano()),
+			Action:    types.ActionAllow,
+		}
+		if err != nil {
+			entry.Action = types.ActionBlock
+			entry.Reason = err.Error()
+		}
+		auditor.Log(entry)
+
+		return res, err
+	}
+}
+
+// Timeout enforces a per-op deadline, falling back to no timeout for ops
+// not present in cfg.
+func Timeout(cfg map[Op]time.Duration) Interceptor {
+	return func(ctx context.Context, op Op, next Handler) (Result, error) {
+		d, ok := cfg[op]
+		if !ok || d <= 0 {
+			return next(ctx)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+
+		type outcome struct {
+			res Result
+			err error
+		}
+		done := make(chan outcome, 1)
+		go func() {
+			res, err := next(ctx)
+			done <- outcome{res, err}
+		}()
+
+		select {
+		case o := <-done:
+			return o.res, o.err
+		case <-ctx.Done():
+			return Result{}, fmt.Errorf("%s timed out after %s: %w", op, d, ctx.Err())
+		}
+	}
+}
+
+// PolicyGate invokes the policy engine uniformly for ops that carry a
+// command to evaluate, attached via WithCommand.
+func PolicyGate(policyEngine *policy.Engine) Interceptor {
+	return func(ctx context.Context, op Op, next Handler) (Result, error) {
+		if policyEngine == nil || op != OpExec {
+			return next(ctx)
+		}
+		command, ok := CommandFromContext(ctx)
+		if !ok {
+			return next(ctx)
+		}
+
+		result := policyEngine.EvaluateCommand(ctx, command)
+		if !result.Allowed {
+			return Result{}, fmt.Errorf("policy blocked: %s (reason: %s)", command, result.Reason)
+		}
+		return next(ctx)
+	}
+}
+
+type contextKey string
+
+const commandContextKey contextKey = "command"
+
+// WithCommand attaches the command string an OpExec handler is about to
+// run so PolicyGate can evaluate it.
+func WithCommand(ctx context.Context, command string) context.Context {
+	return context.WithValue(ctx, commandContextKey, command)
+}
+
+// CommandFromContext retrieves the command attached by WithCommand.
+func CommandFromContext(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(commandContextKey).(string)
+	return v, ok
+}
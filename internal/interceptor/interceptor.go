@@ -0,0 +1,58 @@
+// Package interceptor provides a gRPC-middleware-style chain for
+// cross-cutting concerns (panic recovery, audit logging, timeouts, policy
+// enforcement) around sandbox.Engine and orchestrator.Orchestrator
+// operations, so those concerns are composed once instead of re-wired in
+// every cmd/*.go and every engine method.
+package interceptor
+
+import "context"
+
+// Op identifies the kind of operation being intercepted.
+type Op string
+
+const (
+	OpCreateSession Op = "create_session"
+	OpStopSession   Op = "stop_session"
+	OpExec          Op = "exec"
+	OpListSessions  Op = "list_sessions"
+	OpSpawnAgent    Op = "spawn_agent"
+	OpStopAgent     Op = "stop_agent"
+)
+
+// Result carries a Handler's return value through the chain. Callers
+// type-assert Value back to the concrete type they expect.
+type Result struct {
+	Value interface{}
+}
+
+// Handler executes the operation at the center of the chain.
+type Handler func(ctx context.Context) (Result, error)
+
+// Interceptor wraps a Handler with cross-cutting behavior. It must call
+// next to continue the chain, or return early to short-circuit it.
+type Interceptor func(ctx context.Context, op Op, next Handler) (Result, error)
+
+// Chain composes interceptors into a single Interceptor. The first
+// interceptor given is outermost (runs first on the way in, last on the
+// way out).
+func Chain(interceptors ...Interceptor) Interceptor {
+	return func(ctx context.Context, op Op, final Handler) (Result, error) {
+		h := final
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			ic := interceptors[i]
+			next := h
+			h = func(ctx context.Context) (Result, error) {
+				return ic(ctx, op, next)
+			}
+		}
+		return h(ctx)
+	}
+}
+
+// Run executes handler through the given interceptors for op.
+func Run(ctx context.Context, op Op, handler Handler, interceptors ...Interceptor) (Result, error) {
+	if len(interceptors) == 0 {
+		return handler(ctx)
+	}
+	return Chain(interceptors...)(ctx, op, handler)
+}
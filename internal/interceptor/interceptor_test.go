@@ -0,0 +1,89 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestChain_OrderAndShortCircuit(t *testing.T) {
+	var calls []string
+
+	tag := func(name string) Interceptor {
+		return func(ctx context.Context, op Op, next Handler) (Result, error) {
+			calls = append(calls, "in:"+name)
+			res, err := next(ctx)
+			calls = append(calls, "out:"+name)
+			return res, err
+		}
+	}
+
+	final := Handler(func(ctx context.Context) (Result, error) {
+		calls = append(calls, "handler")
+		return Result{Value: "ok"}, nil
+	})
+
+	res, err := Run(context.Background(), OpExec, final, tag("a"), tag("b"))
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if res.Value != "ok" {
+		t.Errorf("Value = %v, want ok", res.Value)
+	}
+
+	want := []string{"in:a", "in:b", "handler", "out:b", "out:a"}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("calls[%d] = %q, want %q", i, calls[i], want[i])
+		}
+	}
+}
+
+func TestRecovery_CatchesPanic(t *testing.T) {
+	panicking := Handler(func(ctx context.Context) (Result, error) {
+		panic("boom")
+	})
+
+	_, err := Run(context.Background(), OpExec, panicking, Recovery(nil))
+	if err == nil {
+		t.Fatal("expected Recovery to convert the panic into an error")
+	}
+}
+
+func TestPolicyGate_SkipsNonExecOps(t *testing.T) {
+	called := false
+	handler := Handler(func(ctx context.Context) (Result, error) {
+		called = true
+		return Result{}, nil
+	})
+
+	_, err := Run(context.Background(), OpListSessions, handler, PolicyGate(nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Error("handler should still run when PolicyGate has nothing to evaluate")
+	}
+}
+
+func TestWithCommand_RoundTrips(t *testing.T) {
+	ctx := WithCommand(context.Background(), "git status")
+	cmd, ok := CommandFromContext(ctx)
+	if !ok || cmd != "git status" {
+		t.Errorf("CommandFromContext = (%q, %v), want (\"git status\", true)", cmd, ok)
+	}
+}
+
+func TestChain_PropagatesError(t *testing.T) {
+	failing := Handler(func(ctx context.Context) (Result, error) {
+		return Result{}, errors.New("boom")
+	})
+
+	_, err := Run(context.Background(), OpExec, failing, Recovery(nil))
+	if err == nil || err.Error() != "boom" {
+		t.Errorf("err = %v, want boom", err)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/MakazhanAlpamys/claudeshield/internal/audit"
+	"github.com/MakazhanAlpamys/claudeshield/internal/interceptor"
 	"github.com/MakazhanAlpamys/claudeshield/internal/sandbox"
 	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
 )
@@ -16,23 +17,57 @@ import (
 // Orchestrator manages multiple parallel agents, each in its own
 // git worktree and Docker container.
 type Orchestrator struct {
-	engine   *sandbox.Engine
-	auditor  *audit.Logger
-	sessions map[string]*types.Session
-	mu       sync.RWMutex
+	engine       *sandbox.Engine
+	auditor      *audit.Logger
+	sessions     map[string]*types.Session
+	mu           sync.RWMutex
+	interceptors []interceptor.Interceptor
+}
+
+// Option configures an Orchestrator at construction time.
+type Option func(*Orchestrator)
+
+// WithInterceptors composes the given interceptors (outermost first)
+// around every SpawnAgent/StopAgent call, so panic recovery, audit
+// logging, and similar cross-cutting concerns don't have to be re-wired
+// by every caller.
+func WithInterceptors(interceptors ...interceptor.Interceptor) Option {
+	return func(o *Orchestrator) {
+		o.interceptors = append(o.interceptors, interceptors...)
+	}
 }
 
 // New creates a new multi-agent orchestrator.
-func New(engine *sandbox.Engine, auditor *audit.Logger) *Orchestrator {
-	return &Orchestrator{
+func New(engine *sandbox.Engine, auditor *audit.Logger, opts ...Option) *Orchestrator {
+	o := &Orchestrator{
 		engine:   engine,
 		auditor:  auditor,
 		sessions: make(map[string]*types.Session),
 	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
 }
 
-// SpawnAgent creates a new agent with its own git worktree and sandbox container.
+// SpawnAgent creates a new agent with its own git worktree and sandbox
+// container, running the call through the interceptors passed to New via
+// WithInterceptors (none by default). With interceptor.Recovery in that
+// chain, a panic from the underlying git/docker shell-outs comes back as an
+// error; a worktree created before the panic is not removed.
 func (o *Orchestrator) SpawnAgent(ctx context.Context, projectDir string, agentName string, cfg types.SandboxConfig) (*types.Session, error) {
+	res, err := interceptor.Run(ctx, interceptor.OpSpawnAgent, func(ctx context.Context) (interceptor.Result, error) {
+		session, err := o.spawnAgent(ctx, projectDir, agentName, cfg)
+		return interceptor.Result{Value: session}, err
+	}, o.interceptors...)
+	if err != nil {
+		return nil, err
+	}
+	session, _ := res.Value.(*types.Session)
+	return session, nil
+}
+
+func (o *Orchestrator) spawnAgent(ctx context.Context, projectDir string, agentName string, cfg types.SandboxConfig) (*types.Session, error) {
 	o.mu.Lock()
 	defer o.mu.Unlock()
 
@@ -66,8 +101,16 @@ func (o *Orchestrator) SpawnAgent(ctx context.Context, projectDir string, agentN
 	return session, nil
 }
 
-// StopAgent stops an agent and cleans up its worktree.
+// StopAgent stops an agent and cleans up its worktree, running the call
+// through the orchestrator's interceptor chain.
 func (o *Orchestrator) StopAgent(ctx context.Context, agentName string, merge bool) error {
+	_, err := interceptor.Run(ctx, interceptor.OpStopAgent, func(ctx context.Context) (interceptor.Result, error) {
+		return interceptor.Result{}, o.stopAgent(ctx, agentName, merge)
+	}, o.interceptors...)
+	return err
+}
+
+func (o *Orchestrator) stopAgent(ctx context.Context, agentName string, merge bool) error {
 	o.mu.Lock()
 	defer o.mu.Unlock()
 
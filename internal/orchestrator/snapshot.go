@@ -0,0 +1,129 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/MakazhanAlpamys/claudeshield/internal/sandbox"
+	"github.com/MakazhanAlpamys/claudeshield/internal/snapshot"
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// CreateSnapshot captures the running agent's worktree commit, sandbox
+// config, and policy rules into an immutable, content-addressed manifest.
+// secretRefs should contain secret names only, never resolved values.
+func (o *Orchestrator) CreateSnapshot(store *snapshot.Store, agentName string, cfg types.SandboxConfig, policyRules types.RulesConfig, secretRefs []string) (*snapshot.Manifest, error) {
+	o.mu.RLock()
+	session, ok := o.sessions[agentName]
+	o.mu.RUnlock()
+	if !ok {
+		sessions, err := o.engine.ListSessions(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("listing sessions: %w", err)
+		}
+		for _, s := range sessions {
+			if s.AgentName == agentName {
+				// An agent's container is created with its worktree as project dir.
+				s.WorktreeDir = s.ProjectDir
+				session, ok = s, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("agent %q not found", agentName)
+	}
+
+	commit, err := gitHead(session.WorktreeDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading worktree HEAD: %w", err)
+	}
+
+	return store.Create(snapshot.Manifest{
+		AgentName:      agentName,
+		GitCommit:      commit,
+		ContainerImage: sandbox.SandboxImage,
+		SandboxConfig:  cfg,
+		PolicyRules:    policyRules,
+		SecretRefs:     secretRefs,
+		CreatedAt:      time.Now(),
+	})
+}
+
+// RestoreAgent hydrates a new worktree/branch from a snapshot's commit and
+// re-applies the manifest's sandbox config verbatim, giving a reproducible
+// replay of a past agent run under a fresh agent name.
+func (o *Orchestrator) RestoreAgent(ctx context.Context, store *snapshot.Store, digestOrAlias, projectDir, newAgentName string) (*types.Session, error) {
+	m, err := store.Load(digestOrAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	o.mu.Lock()
+	if _, exists := o.sessions[newAgentName]; exists {
+		o.mu.Unlock()
+		return nil, fmt.Errorf("agent %q already exists", newAgentName)
+	}
+	o.mu.Unlock()
+
+	absProjectDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving project dir: %w", err)
+	}
+
+	worktreeDir, err := createWorktreeFromCommit(absProjectDir, newAgentName, m.GitCommit)
+	if err != nil {
+		return nil, fmt.Errorf("hydrating worktree from snapshot %s: %w", m.Digest, err)
+	}
+
+	session, err := o.engine.CreateSession(ctx, worktreeDir, m.SandboxConfig, newAgentName, nil)
+	if err != nil {
+		_ = removeWorktree(absProjectDir, worktreeDir)
+		return nil, fmt.Errorf("creating sandbox for restored agent %s: %w", newAgentName, err)
+	}
+	session.WorktreeDir = worktreeDir
+
+	o.mu.Lock()
+	o.sessions[newAgentName] = session
+	o.mu.Unlock()
+
+	return session, nil
+}
+
+func gitHead(dir string) (string, error) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse HEAD: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func createWorktreeFromCommit(projectDir, agentName, commit string) (string, error) {
+	branchName := "claudeshield/" + agentName
+	worktreeDir := filepath.Join(projectDir, ".claudeshield", "worktrees", agentName)
+
+	pruneCmd := exec.Command("git", "-C", projectDir, "worktree", "prune")
+	_ = pruneCmd.Run()
+
+	_ = os.RemoveAll(worktreeDir)
+
+	delCmd := exec.Command("git", "-C", projectDir, "branch", "-D", branchName)
+	_ = delCmd.Run()
+
+	cmd := exec.Command("git", "-C", projectDir, "branch", branchName, commit)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("git branch from %s: %s: %w", commit, string(out), err)
+	}
+
+	cmd = exec.Command("git", "-C", projectDir, "worktree", "add", worktreeDir, branchName)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("git worktree add: %s: %w", string(out), err)
+	}
+
+	return worktreeDir, nil
+}
@@ -1,12 +1,48 @@
 package policy
 
 import (
+	"context"
+	"fmt"
 	"path/filepath"
 	"strings"
 
 	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
 )
 
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	runnerIDKey
+)
+
+// WithRequestID attaches a correlation ID for one host-level tool
+// invocation, so every Result/AuditEntry it produces (a command eval, any
+// file evals nested under it) can be tied back together in the JSONL
+// stream. Mirrors CrowdSec's appsec request-context propagation.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestIDFromContext retrieves the ID attached by WithRequestID, if any.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// WithRunnerID attaches the identity of the agent goroutine/worker that is
+// about to evaluate a command or file access, so concurrent agents'
+// entries are distinguishable in the audit log.
+func WithRunnerID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, runnerIDKey, id)
+}
+
+// RunnerIDFromContext retrieves the ID attached by WithRunnerID, if any.
+func RunnerIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(runnerIDKey).(string)
+	return id
+}
+
 // Engine evaluates commands and file accesses against the policy rules.
 type Engine struct {
 	config *types.ProjectConfig
@@ -28,46 +64,191 @@ type Result struct {
 	Action  types.PolicyAction
 	Rule    *types.Rule
 	Reason  string
+	// RequestID and RunnerID are copied from ctx (see WithRequestID/
+	// WithRunnerID) so callers can log them onto the resulting AuditEntry
+	// without threading the context any further themselves.
+	RequestID string
+	RunnerID  string
+	// MatchedZones names the parts of the evaluated input that triggered
+	// Rule (or the fail-secure default), e.g. ["args[2]", "env.PATH"] for
+	// a command, ["basename"] or ["dirname"] for a file path.
+	MatchedZones []string
+	// WouldBlock is true when the matched rule's exec-scope action denies,
+	// even if EnforcementMode let the command proceed anyway — so callers
+	// can tell "would have blocked" from "did block" (Allowed).
+	WouldBlock bool
+	// EnforcementMode is the mode this Result was evaluated under (see
+	// types.ProjectConfig.EnforcementMode), echoed so audit consumers don't
+	// need to reload config to explain a WouldBlock/Allowed mismatch.
+	EnforcementMode types.EnforcementMode
+	// AuditSeverity is the rule's audit-scope action (e.g. "warn" while exec
+	// still denies), or the exec-scope action when the rule has no
+	// audit-scope entry, for callers that want a softer log severity than
+	// the enforcement outcome.
+	AuditSeverity types.PolicyAction
+}
+
+// isDenyAction reports whether action should stop something from
+// happening, treating ActionDeny as a synonym for ActionBlock.
+func isDenyAction(action types.PolicyAction) bool {
+	return action == types.ActionBlock || action == types.ActionDeny
+}
+
+// scopedAction looks up rule's action override for scope, falling back to
+// fallback when rule is nil (the fail-secure default has no rule to scope)
+// or has no entry for that scope.
+func scopedAction(rule *types.Rule, scope string, fallback types.PolicyAction) types.PolicyAction {
+	if rule == nil {
+		return fallback
+	}
+	for _, sa := range rule.Actions {
+		if sa.Scope == scope {
+			return sa.Action
+		}
+	}
+	return fallback
+}
+
+// applyEnforcement resolves a matched rule's effective exec-scope action
+// (falling back to defaultAction for the fail-secure path, where rule is
+// nil) and then applies the engine's global EnforcementMode: "enforce"
+// runs the rule as configured, while "dryrun"/"warn" let the command
+// proceed regardless, recording the would-be decision on res instead of
+// acting on it.
+func (e *Engine) applyEnforcement(res *Result, rule *types.Rule, defaultAction types.PolicyAction) {
+	execAction := scopedAction(rule, "exec", defaultAction)
+	res.WouldBlock = isDenyAction(execAction)
+	res.AuditSeverity = scopedAction(rule, "audit", execAction)
+
+	res.EnforcementMode = e.config.EnforcementMode
+	if res.EnforcementMode == "" {
+		res.EnforcementMode = types.EnforcementEnforce
+	}
+
+	if res.EnforcementMode == types.EnforcementEnforce {
+		res.Allowed = !res.WouldBlock
+	} else {
+		res.Allowed = true
+	}
+
+	if res.Allowed {
+		res.Action = types.ActionAllow
+	} else {
+		res.Action = types.ActionBlock
+	}
 }
 
-// EvaluateCommand checks if a command is allowed by the policy.
-func (e *Engine) EvaluateCommand(command string) Result {
+// EvaluateCommand checks if a command is allowed by the policy. ctx carries
+// the RequestID/RunnerID to stamp onto the Result (see WithRequestID,
+// WithRunnerID); pass context.Background() if neither applies.
+func (e *Engine) EvaluateCommand(ctx context.Context, command string) Result {
 	command = strings.TrimSpace(command)
+	base := Result{RequestID: RequestIDFromContext(ctx), RunnerID: RunnerIDFromContext(ctx)}
 
 	// Check block rules first (deny takes priority)
 	for i, rule := range e.config.Rules.Block {
 		if matchPattern(rule.Pattern, command) {
-			return Result{
-				Allowed: false,
-				Action:  types.ActionBlock,
-				Rule:    &e.config.Rules.Block[i],
-				Reason:  rule.Reason,
-			}
+			base.Rule = &e.config.Rules.Block[i]
+			base.Reason = rule.Reason
+			base.MatchedZones = commandMatchZones(command, rule.Pattern)
+			e.applyEnforcement(&base, base.Rule, types.ActionBlock)
+			return base
 		}
 	}
 
-	// Check allow rules
+	// Check allow rules. Allow rules never block, so scoped actions and
+	// EnforcementMode — both only meaningful for a deny decision — don't
+	// apply here.
 	for i, rule := range e.config.Rules.Allow {
 		if matchPattern(rule.Pattern, command) {
-			return Result{
-				Allowed: true,
-				Action:  types.ActionAllow,
-				Rule:    &e.config.Rules.Allow[i],
-			}
+			base.Allowed = true
+			base.Action = types.ActionAllow
+			base.Rule = &e.config.Rules.Allow[i]
+			base.MatchedZones = commandMatchZones(command, rule.Pattern)
+			return base
 		}
 	}
 
 	// Default: block unknown commands (fail-secure)
+	base.Reason = "Command not in allowlist"
+	base.MatchedZones = commandMatchZones(command, "")
+	e.applyEnforcement(&base, nil, types.ActionBlock)
+	return base
+}
+
+// commandMatchZones names which whitespace-delimited parts of command line
+// up with pattern, so an operator can tell a binary-name block from an
+// argument-content block at a glance. Tokens that look like "KEY=VALUE" env
+// assignments are reported as "env.KEY" instead of their position.
+func commandMatchZones(command, pattern string) []string {
+	tokens := strings.Fields(command)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	needle := strings.Trim(pattern, "*")
+	needle = strings.TrimSuffix(needle, " ")
+
+	var zones []string
+	for i, tok := range tokens {
+		if pattern == "" || strings.Contains(tok, needle) || strings.Contains(needle, tok) {
+			zones = append(zones, zoneLabel(i, tok))
+		}
+	}
+	if len(zones) == 0 {
+		zones = []string{zoneLabel(0, tokens[0])}
+	}
+	return zones
+}
+
+func zoneLabel(index int, token string) string {
+	if key, _, ok := strings.Cut(token, "="); ok && key != "" && isEnvKey(key) {
+		return "env." + key
+	}
+	if index == 0 {
+		return "args[0]"
+	}
+	return fmt.Sprintf("args[%d]", index)
+}
+
+func isEnvKey(s string) bool {
+	for i, r := range s {
+		isLetter := (r >= 'A' && r <= 'Z') || r == '_'
+		isDigit := r >= '0' && r <= '9'
+		if i == 0 && !isLetter {
+			return false
+		}
+		if !isLetter && !isDigit {
+			return false
+		}
+	}
+	return true
+}
+
+// EvaluateDevice checks a requested CDI device spec (e.g. "nvidia.com/gpu=0")
+// against the Rules.Devices allow-list. Unlike commands, there is no
+// block-list for devices — the allow-list is the only control, and an empty
+// one rejects every device (fail-secure, same default posture as
+// EvaluateCommand).
+func (e *Engine) EvaluateDevice(spec string) Result {
+	for _, pattern := range e.config.Rules.Devices {
+		if matchPattern(pattern, spec) {
+			return Result{Allowed: true, Action: types.ActionAllow}
+		}
+	}
+
 	return Result{
 		Allowed: false,
 		Action:  types.ActionBlock,
-		Reason:  "Command not in allowlist",
+		Reason:  "Device not in allowlist: " + spec,
 	}
 }
 
-// EvaluateFileAccess checks if access to a file path is allowed.
-func (e *Engine) EvaluateFileAccess(path string) Result {
-	base := filepath.Base(path)
+// EvaluateFileAccess checks if access to a file path is allowed. ctx carries
+// the RequestID/RunnerID to stamp onto the Result, same as EvaluateCommand.
+func (e *Engine) EvaluateFileAccess(ctx context.Context, path string) Result {
+	base := Result{RequestID: RequestIDFromContext(ctx), RunnerID: RunnerIDFromContext(ctx)}
+	name := filepath.Base(path)
 
 	// Block sensitive filenames
 	sensitiveFiles := []string{
@@ -75,23 +256,21 @@ func (e *Engine) EvaluateFileAccess(path string) Result {
 		".bash_history", ".zsh_history",
 		".gitconfig", "id_rsa", "id_ed25519",
 	}
-	for _, name := range sensitiveFiles {
-		if base == name {
-			return Result{
-				Allowed: false,
-				Action:  types.ActionBlock,
-				Reason:  "Access to sensitive file blocked: " + path,
-			}
+	for _, sensitive := range sensitiveFiles {
+		if name == sensitive {
+			base.Reason = "Access to sensitive file blocked: " + path
+			base.MatchedZones = []string{"basename"}
+			e.applyEnforcement(&base, nil, types.ActionBlock)
+			return base
 		}
 	}
 
 	// Block .env.* variants (e.g. .env.local, .env.production)
-	if strings.HasPrefix(base, ".env.") {
-		return Result{
-			Allowed: false,
-			Action:  types.ActionBlock,
-			Reason:  "Access to sensitive file blocked: " + path,
-		}
+	if strings.HasPrefix(name, ".env.") {
+		base.Reason = "Access to sensitive file blocked: " + path
+		base.MatchedZones = []string{"basename"}
+		e.applyEnforcement(&base, nil, types.ActionBlock)
+		return base
 	}
 
 	// Block sensitive directories
@@ -99,37 +278,33 @@ func (e *Engine) EvaluateFileAccess(path string) Result {
 	dir := filepath.Dir(path)
 	for _, sd := range sensitiveParents {
 		if filepath.Base(dir) == sd || strings.Contains(path, "/"+sd+"/") {
-			return Result{
-				Allowed: false,
-				Action:  types.ActionBlock,
-				Reason:  "Access to sensitive file blocked: " + path,
-			}
+			base.Reason = "Access to sensitive file blocked: " + path
+			base.MatchedZones = []string{"dirname"}
+			e.applyEnforcement(&base, nil, types.ActionBlock)
+			return base
 		}
 	}
 
 	// Block docker config specifically
-	if base == "config.json" && strings.Contains(path, ".docker") {
-		return Result{
-			Allowed: false,
-			Action:  types.ActionBlock,
-			Reason:  "Access to sensitive file blocked: " + path,
-		}
+	if name == "config.json" && strings.Contains(path, ".docker") {
+		base.Reason = "Access to sensitive file blocked: " + path
+		base.MatchedZones = []string{"dirname", "basename"}
+		e.applyEnforcement(&base, nil, types.ActionBlock)
+		return base
 	}
 
 	// Allow access to workspace
 	if strings.HasPrefix(path, "/workspace") {
-		return Result{
-			Allowed: true,
-			Action:  types.ActionAllow,
-		}
+		base.Allowed = true
+		base.Action = types.ActionAllow
+		return base
 	}
 
 	// Block everything outside workspace
-	return Result{
-		Allowed: false,
-		Action:  types.ActionBlock,
-		Reason:  "Access outside workspace blocked: " + path,
-	}
+	base.Reason = "Access outside workspace blocked: " + path
+	base.MatchedZones = []string{"dirname"}
+	e.applyEnforcement(&base, nil, types.ActionBlock)
+	return base
 }
 
 // matchPattern performs glob-style pattern matching on commands.
@@ -1,6 +1,7 @@
 package policy
 
 import (
+	"context"
 	"testing"
 
 	"github.com/MakazhanAlpamys/claudeshield/internal/config"
@@ -29,7 +30,7 @@ func TestEvaluateCommand_AllowGit(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.cmd, func(t *testing.T) {
-			result := engine.EvaluateCommand(tt.cmd)
+			result := engine.EvaluateCommand(context.Background(), tt.cmd)
 			if result.Allowed != tt.allowed {
 				t.Errorf("EvaluateCommand(%q) = %v, want %v (reason: %s)", tt.cmd, result.Allowed, tt.allowed, result.Reason)
 			}
@@ -53,7 +54,7 @@ func TestEvaluateCommand_BlockDangerous(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.cmd, func(t *testing.T) {
-			result := engine.EvaluateCommand(tt.cmd)
+			result := engine.EvaluateCommand(context.Background(), tt.cmd)
 			if result.Allowed {
 				t.Errorf("EvaluateCommand(%q) should be blocked", tt.cmd)
 			}
@@ -68,7 +69,7 @@ func TestEvaluateCommand_BlockUnknown(t *testing.T) {
 	cfg := config.DefaultConfig()
 	engine := New(cfg)
 
-	result := engine.EvaluateCommand("some-unknown-command --flag")
+	result := engine.EvaluateCommand(context.Background(), "some-unknown-command --flag")
 	if result.Allowed {
 		t.Error("unknown commands should be blocked by default (fail-secure)")
 	}
@@ -87,7 +88,7 @@ func TestEvaluateFileAccess_BlockSensitive(t *testing.T) {
 
 	for _, path := range sensitive {
 		t.Run(path, func(t *testing.T) {
-			result := engine.EvaluateFileAccess(path)
+			result := engine.EvaluateFileAccess(context.Background(), path)
 			if result.Allowed {
 				t.Errorf("access to %q should be blocked", path)
 			}
@@ -107,7 +108,7 @@ func TestEvaluateFileAccess_AllowWorkspace(t *testing.T) {
 
 	for _, path := range allowed {
 		t.Run(path, func(t *testing.T) {
-			result := engine.EvaluateFileAccess(path)
+			result := engine.EvaluateFileAccess(context.Background(), path)
 			if !result.Allowed {
 				t.Errorf("access to %q should be allowed (reason: %s)", path, result.Reason)
 			}
@@ -119,7 +120,7 @@ func TestEvaluateFileAccess_BlockOutsideWorkspace(t *testing.T) {
 	cfg := config.DefaultConfig()
 	engine := New(cfg)
 
-	result := engine.EvaluateFileAccess("/etc/passwd")
+	result := engine.EvaluateFileAccess(context.Background(), "/etc/passwd")
 	if result.Allowed {
 		t.Error("access outside workspace should be blocked")
 	}
@@ -138,9 +139,88 @@ func TestBlockRulesTakePriority(t *testing.T) {
 	}
 
 	engine := New(cfg)
-	result := engine.EvaluateCommand("rm -rf /")
+	result := engine.EvaluateCommand(context.Background(), "rm -rf /")
 
 	if result.Allowed {
 		t.Error("block rules should take priority over allow rules")
 	}
 }
+
+func TestScopedActions_WarnAuditScopeDoesNotOverrideBlock(t *testing.T) {
+	cfg := &types.ProjectConfig{
+		Rules: types.RulesConfig{
+			Allow: []types.Rule{
+				{Pattern: "rm *", Action: types.ActionAllow},
+			},
+			Block: []types.Rule{
+				{
+					Pattern: "rm -rf /",
+					Action:  types.ActionBlock,
+					Reason:  "blocked",
+					Actions: []types.ScopedAction{{Scope: "audit", Action: types.ActionWarn}},
+				},
+			},
+		},
+	}
+
+	engine := New(cfg)
+	result := engine.EvaluateCommand(context.Background(), "rm -rf /")
+
+	if result.Allowed {
+		t.Error("a warn-scope audit action should not override the block-scope rule's allow/exec decision")
+	}
+	if !result.WouldBlock {
+		t.Error("expected WouldBlock to be true")
+	}
+	if result.AuditSeverity != types.ActionWarn {
+		t.Errorf("expected AuditSeverity %q, got %q", types.ActionWarn, result.AuditSeverity)
+	}
+}
+
+func TestScopedActions_ExecDenyWinsOverAllow(t *testing.T) {
+	cfg := &types.ProjectConfig{
+		Rules: types.RulesConfig{
+			Allow: []types.Rule{
+				{Pattern: "rm *", Action: types.ActionAllow},
+			},
+			Block: []types.Rule{
+				{
+					Pattern: "rm -rf /",
+					Action:  types.ActionAllow, // plain Action says allow...
+					Actions: []types.ScopedAction{{Scope: "exec", Action: types.ActionDeny}},
+				},
+			},
+		},
+	}
+
+	engine := New(cfg)
+	result := engine.EvaluateCommand(context.Background(), "rm -rf /")
+
+	if result.Allowed {
+		t.Error("an exec-scope deny should win even when the rule's plain Action is allow")
+	}
+}
+
+func TestEnforcementMode_DryRunLetsCommandsProceed(t *testing.T) {
+	cfg := &types.ProjectConfig{
+		EnforcementMode: types.EnforcementDryRun,
+		Rules: types.RulesConfig{
+			Block: []types.Rule{
+				{Pattern: "rm -rf /", Action: types.ActionBlock, Reason: "blocked"},
+			},
+		},
+	}
+
+	engine := New(cfg)
+	result := engine.EvaluateCommand(context.Background(), "rm -rf /")
+
+	if !result.Allowed {
+		t.Error("dryrun mode should let the command proceed")
+	}
+	if !result.WouldBlock {
+		t.Error("dryrun mode should still record that the rule would have blocked")
+	}
+	if result.EnforcementMode != types.EnforcementDryRun {
+		t.Errorf("expected EnforcementMode %q, got %q", types.EnforcementDryRun, result.EnforcementMode)
+	}
+}
@@ -0,0 +1,153 @@
+package rollback
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+	"github.com/checkpoint-restore/go-criu/v6/stats"
+	"github.com/docker/docker/api/types/checkpoint"
+	"github.com/docker/docker/api/types/container"
+)
+
+// criuMetadata is written alongside a CRIU dump tree so Rollback can
+// reconstruct the container config and report dump stats without
+// re-invoking CRIU.
+type criuMetadata struct {
+	Image          string                `json:"image"`
+	Config         *container.Config     `json:"config"`
+	HostConfig     *container.HostConfig `json:"host_config"`
+	DumpStats      *stats.DumpStatsEntry `json:"dump_stats,omitempty"`
+	TCPEstablished bool                  `json:"tcp_established"`
+}
+
+// CreateCRIUCheckpoint dumps the full process tree of session's container —
+// memory, open file descriptors, and established connections — via CRIU,
+// so Rollback resumes the agent instead of starting a fresh process.
+//
+// The container is paused for the duration of the dump (CRIU requires a
+// quiescent process tree). tcpEstablished is recorded in the dump's
+// metadata.json only; it is not passed to the dump itself.
+func (m *Manager) CreateCRIUCheckpoint(ctx context.Context, session *types.Session, description string, tcpEstablished bool) (*types.Checkpoint, error) {
+	if err := checkCRIUAvailable(); err != nil {
+		return nil, err
+	}
+
+	cpID := fmt.Sprintf("cs-cp-%d", time.Now().UnixMilli())
+	dumpDir := filepath.Join(m.checkpointDir(session.ID), cpID)
+	if err := os.MkdirAll(dumpDir, 0700); err != nil {
+		return nil, fmt.Errorf("creating CRIU dump dir: %w", err)
+	}
+
+	inspect, err := m.client.ContainerInspect(ctx, session.ContainerID)
+	if err != nil {
+		return nil, fmt.Errorf("inspecting container before dump: %w", err)
+	}
+
+	err = m.client.CheckpointCreate(ctx, session.ContainerID, checkpoint.CreateOptions{
+		CheckpointID:  cpID,
+		CheckpointDir: dumpDir,
+		Exit:          false,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CRIU dump failed (is the container in a dumpable state?): %w", err)
+	}
+
+	meta := criuMetadata{
+		Image:          inspect.Image,
+		Config:         inspect.Config,
+		HostConfig:     inspect.HostConfig,
+		TCPEstablished: tcpEstablished,
+	}
+	if dumpDirFile, err := os.Open(dumpDir); err == nil {
+		if dumpStats, err := stats.CriuGetDumpStats(dumpDirFile); err == nil {
+			meta.DumpStats = dumpStats
+		}
+		dumpDirFile.Close()
+	}
+
+	metaData, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding CRIU metadata: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dumpDir, "metadata.json"), metaData, 0600); err != nil {
+		return nil, fmt.Errorf("writing CRIU metadata: %w", err)
+	}
+
+	cp := types.Checkpoint{
+		ID:             cpID,
+		SessionID:      session.ID,
+		CheckpointMode: types.CheckpointModeCRIU,
+		DumpPath:       dumpDir,
+		Description:    description,
+		CreatedAt:      time.Now(),
+	}
+
+	m.checkpoints[session.ID] = append(m.checkpoints[session.ID], cp)
+	_ = m.save()
+	return &cp, nil
+}
+
+// rollbackCRIU restores session's container from a CRIU dump tree rather
+// than recreating it from a committed image. The bind mounts configured on
+// the original container must still be present at the same host paths —
+// CRIU restores file descriptors by path, not by content.
+func (m *Manager) rollbackCRIU(ctx context.Context, session *types.Session, target *types.Checkpoint) error {
+	if err := checkCRIUAvailable(); err != nil {
+		return err
+	}
+
+	metaData, err := os.ReadFile(filepath.Join(target.DumpPath, "metadata.json"))
+	if err != nil {
+		return fmt.Errorf("reading CRIU metadata: %w", err)
+	}
+	var meta criuMetadata
+	if err := json.Unmarshal(metaData, &meta); err != nil {
+		return fmt.Errorf("decoding CRIU metadata: %w", err)
+	}
+
+	timeout := 5
+	_ = m.client.ContainerStop(ctx, session.ContainerID, container.StopOptions{Timeout: &timeout})
+	_ = m.client.ContainerRemove(ctx, session.ContainerID, container.RemoveOptions{})
+
+	resp, err := m.client.ContainerCreate(ctx, meta.Config, meta.HostConfig, nil, nil, session.ID)
+	if err != nil {
+		return fmt.Errorf("recreating container shell for CRIU restore: %w", err)
+	}
+
+	err = m.client.ContainerStart(ctx, resp.ID, container.StartOptions{
+		CheckpointID:  target.ID,
+		CheckpointDir: target.DumpPath,
+	})
+	if err != nil {
+		return fmt.Errorf("CRIU restore failed: %w", err)
+	}
+
+	session.ContainerID = resp.ID
+	session.UpdatedAt = time.Now()
+	return nil
+}
+
+// checkCRIUAvailable surfaces a clear error up front rather than letting a
+// dump/restore fail deep inside the Docker API with an opaque message.
+func checkCRIUAvailable() error {
+	if _, err := exec.LookPath("criu"); err != nil {
+		return fmt.Errorf("CRIU checkpoint/restore requires the 'criu' binary on the host: %w", err)
+	}
+	return nil
+}
+
+// checkpointDir returns the on-disk root for a session's CRIU dump trees,
+// alongside the existing JSON-backed checkpoint index.
+func (m *Manager) checkpointDir(sessionID string) string {
+	base := m.storagePath
+	if base == "" {
+		base = os.TempDir()
+	}
+	return filepath.Join(filepath.Dir(base), "criu", sessionID)
+}
@@ -75,11 +75,12 @@ func (m *Manager) CreateCheckpoint(ctx context.Context, session *types.Session,
 	}
 
 	cp := types.Checkpoint{
-		ID:          cpID,
-		SessionID:   session.ID,
-		ImageID:     commitResp.ID,
-		Description: description,
-		CreatedAt:   time.Now(),
+		ID:             cpID,
+		SessionID:      session.ID,
+		CheckpointMode: types.CheckpointModeFS,
+		ImageID:        commitResp.ID,
+		Description:    description,
+		CreatedAt:      time.Now(),
 	}
 
 	m.checkpoints[session.ID] = append(m.checkpoints[session.ID], cp)
@@ -105,6 +106,10 @@ func (m *Manager) Rollback(ctx context.Context, session *types.Session, checkpoi
 		return fmt.Errorf("checkpoint %s not found", checkpointID)
 	}
 
+	if target.CheckpointMode == types.CheckpointModeCRIU {
+		return m.rollbackCRIU(ctx, session, target)
+	}
+
 	// Stop current container
 	timeout := 5
 	stopOpts := container.StopOptions{Timeout: &timeout}
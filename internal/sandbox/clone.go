@@ -0,0 +1,149 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+	"github.com/docker/docker/api/types/container"
+)
+
+// CloneOptions overrides applied when forking a running session, Podman
+// "container clone" style. Zero values leave the corresponding source
+// setting untouched, except Secrets: the source's secret env vars are never
+// copied, so a nil Secrets leaves the clone without them.
+type CloneOptions struct {
+	Name       string
+	Memory     string
+	CPUs       float64
+	CPUSetCPUs string
+	// Network, if non-nil, overrides the clone's network mode (true = the
+	// source's default bridge, false = "none").
+	Network *bool
+	// Image swaps the clone onto a different base image — e.g. a checkpoint
+	// commit's image ID resolved by the caller via rollback.Store, for
+	// --from-checkpoint.
+	Image string
+	// Secrets are freshly loaded and injected in place of the source's
+	// secret env vars (see the "claudeshield.secret_keys" label).
+	Secrets map[string]string
+	// Destroy stops and removes the source session once the clone is running.
+	Destroy bool
+}
+
+// CloneSession forks src into a new session: its Config and HostConfig are
+// read back via ContainerInspect, deep-copied, and adjusted per overrides.
+// Mounts, labels, and the policy mount carry over untouched; env is rebuilt
+// from the source's Env minus any key recorded in its secret_keys label, so
+// re-derived secrets never get silently copied forward.
+func (e *Engine) CloneSession(ctx context.Context, src *types.Session, overrides CloneOptions) (*types.Session, error) {
+	inspect, err := e.client.ContainerInspect(ctx, src.ContainerID)
+	if err != nil {
+		return nil, fmt.Errorf("inspecting source container: %w", err)
+	}
+	if inspect.Config == nil || inspect.HostConfig == nil {
+		return nil, fmt.Errorf("source container %s has no inspectable config", src.ContainerID)
+	}
+
+	agentName := overrides.Name
+	if agentName == "" {
+		agentName = src.AgentName
+	}
+	sessionID := fmt.Sprintf("cs-%s-%d", agentName, time.Now().UnixMilli())
+
+	containerCfg := *inspect.Config
+	hostCfg := *inspect.HostConfig
+
+	if overrides.Image != "" {
+		containerCfg.Image = overrides.Image
+	}
+
+	secretKeys := strings.Split(inspect.Config.Labels["claudeshield.secret_keys"], ",")
+	envVars := make([]string, 0, len(inspect.Config.Env))
+	for _, kv := range inspect.Config.Env {
+		key := strings.SplitN(kv, "=", 2)[0]
+		if containsString(secretKeys, key) {
+			continue
+		}
+		envVars = append(envVars, kv)
+	}
+	for k, v := range overrides.Secrets {
+		envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
+	}
+	containerCfg.Env = envVars
+
+	labels := make(map[string]string, len(inspect.Config.Labels))
+	for k, v := range inspect.Config.Labels {
+		labels[k] = v
+	}
+	labels["claudeshield.session"] = sessionID
+	labels["claudeshield.agent"] = agentName
+	containerCfg.Labels = labels
+
+	if overrides.Memory != "" {
+		if mem := parseMemoryLimit(overrides.Memory); mem > 0 {
+			hostCfg.Resources.Memory = mem
+		}
+	}
+	if overrides.CPUs > 0 {
+		hostCfg.Resources.NanoCPUs = int64(overrides.CPUs * 1e9)
+	}
+	if overrides.CPUSetCPUs != "" {
+		hostCfg.Resources.CpusetCpus = overrides.CPUSetCPUs
+	}
+	if overrides.Network != nil {
+		if *overrides.Network {
+			hostCfg.NetworkMode = ""
+		} else {
+			hostCfg.NetworkMode = "none"
+		}
+	}
+
+	resp, err := e.client.ContainerCreate(ctx, &containerCfg, &hostCfg, nil, nil, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("creating cloned container: %w", err)
+	}
+
+	if err := e.client.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		return nil, fmt.Errorf("starting cloned container: %w", err)
+	}
+
+	clone := &types.Session{
+		ID:          sessionID,
+		ProjectDir:  src.ProjectDir,
+		ContainerID: resp.ID,
+		State:       types.SessionRunning,
+		AgentName:   agentName,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if e.auditor != nil {
+		e.auditor.Log(types.AuditEntry{
+			Timestamp: time.Now(),
+			SessionID: sessionID,
+			AgentName: agentName,
+			EventType: "session_cloned",
+			Action:    types.ActionAllow,
+		})
+	}
+
+	if overrides.Destroy {
+		if err := e.StopSession(ctx, src); err != nil {
+			return clone, fmt.Errorf("clone started, but destroying source session failed: %w", err)
+		}
+	}
+
+	return clone, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
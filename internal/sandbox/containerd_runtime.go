@@ -0,0 +1,358 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/MakazhanAlpamys/claudeshield/internal/audit"
+	"github.com/MakazhanAlpamys/claudeshield/internal/policy"
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/oci"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const (
+	containerdNamespace = "claudeshield"
+	containerdSocket    = "/run/containerd/containerd.sock"
+)
+
+// ContainerdRuntime is the containerd-backed Runtime implementation. It
+// talks to a local containerd daemon over its default socket, namespaced so
+// ClaudeShield's containers never collide with ones created by other
+// containerd clients (k8s, nerdctl, ...) on the same host.
+type ContainerdRuntime struct {
+	client  *containerd.Client
+	auditor *audit.Logger
+	policy  *policy.Engine
+}
+
+// NewContainerdRuntime connects to the local containerd daemon.
+func NewContainerdRuntime(auditor *audit.Logger, policyEngine *policy.Engine) (*ContainerdRuntime, error) {
+	cli, err := containerd.New(containerdSocket)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to containerd at %s: %w", containerdSocket, err)
+	}
+	return &ContainerdRuntime{client: cli, auditor: auditor, policy: policyEngine}, nil
+}
+
+func (r *ContainerdRuntime) ctx() context.Context {
+	return namespaces.WithNamespace(context.Background(), containerdNamespace)
+}
+
+// CreateSession pulls SandboxImage (if needed) and starts a task from it,
+// mirroring Engine.CreateSession's container shape: a bind mount of
+// projectDir at /workspace, sleeping until a command is exec'd into it. It
+// applies the same subset of cfg that has a direct containerd equivalent
+// (memory/CPU limits, ReadOnly bind mounts, the Network toggle) and rejects
+// cfg.Devices outright rather than silently dropping it, since this oci
+// package has no CDI support to honor it with.
+func (r *ContainerdRuntime) CreateSession(ctx context.Context, projectDir string, cfg types.SandboxConfig, agentName string, secrets map[string]string) (*types.Session, error) {
+	if len(cfg.Devices) > 0 {
+		return nil, fmt.Errorf("sandbox.devices is not supported by the containerd runtime (no CDI support); use runtime: docker or drop the devices list")
+	}
+
+	ctx = namespaces.WithNamespace(ctx, containerdNamespace)
+	sessionID := fmt.Sprintf("cs-%s-%d", agentName, time.Now().UnixMilli())
+
+	image, err := r.client.Pull(ctx, SandboxImage, containerd.WithPullUnpack)
+	if err != nil {
+		return nil, fmt.Errorf("pulling sandbox image %s: %w", SandboxImage, err)
+	}
+
+	var envVars []string
+	for k, v := range secrets {
+		envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
+	}
+	for k, v := range cfg.Env {
+		envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	mounts := []specs.Mount{
+		{
+			Destination: "/workspace",
+			Type:        "bind",
+			Source:      projectDir,
+			Options:     []string{"rbind", "rw"},
+		},
+	}
+	for _, ro := range cfg.ReadOnly {
+		mounts = append(mounts, specs.Mount{
+			Destination: ro,
+			Type:        "bind",
+			Source:      ro,
+			Options:     []string{"rbind", "ro"},
+		})
+	}
+
+	specOpts := []oci.SpecOpts{
+		oci.WithImageConfig(image),
+		oci.WithProcessArgs("sleep", "infinity"),
+		oci.WithEnv(envVars),
+		oci.WithMounts(mounts),
+	}
+
+	if mem := parseMemoryLimit(cfg.MemoryLimit); mem > 0 {
+		specOpts = append(specOpts, oci.WithMemoryLimit(uint64(mem)))
+	}
+	if cfg.CPULimit > 0 {
+		const period = 100000
+		specOpts = append(specOpts, oci.WithCPUCFS(int64(cfg.CPULimit*period), period))
+	}
+	if cfg.Network {
+		// No CNI plugin is configured for ClaudeShield's containerd
+		// containers, so the only way to grant network access is to join
+		// the host's network namespace; the default (omitting this)
+		// leaves the container in its own unconfigured netns, matching
+		// the Docker backend's NetworkMode "none".
+		specOpts = append(specOpts, oci.WithHostNamespace(specs.NetworkNamespace))
+	}
+
+	container, err := r.client.NewContainer(
+		ctx,
+		sessionID,
+		containerd.WithImage(image),
+		containerd.WithNewSnapshot(sessionID+"-snapshot", image),
+		containerd.WithNewSpec(specOpts...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating containerd container: %w", err)
+	}
+
+	task, err := container.NewTask(ctx, cio.NewCreator(cio.WithStdio))
+	if err != nil {
+		return nil, fmt.Errorf("creating containerd task: %w", err)
+	}
+
+	if err := task.Start(ctx); err != nil {
+		return nil, fmt.Errorf("starting containerd task: %w", err)
+	}
+
+	session := &types.Session{
+		ID:          sessionID,
+		ProjectDir:  projectDir,
+		ContainerID: container.ID(),
+		State:       types.SessionRunning,
+		AgentName:   agentName,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if r.auditor != nil {
+		r.auditor.Log(types.AuditEntry{
+			Timestamp: time.Now(),
+			SessionID: sessionID,
+			AgentName: agentName,
+			EventType: "session_created",
+			Action:    types.ActionAllow,
+		})
+	}
+
+	return session, nil
+}
+
+// StopSession kills the task and deletes the container and its snapshot.
+func (r *ContainerdRuntime) StopSession(ctx context.Context, session *types.Session) error {
+	ctx = r.ctx()
+
+	container, err := r.client.LoadContainer(ctx, session.ContainerID)
+	if err != nil {
+		return fmt.Errorf("loading containerd container: %w", err)
+	}
+
+	task, err := container.Task(ctx, nil)
+	if err == nil {
+		if err := task.Kill(ctx, syscall.SIGKILL); err != nil {
+			return fmt.Errorf("killing containerd task: %w", err)
+		}
+		if _, err := task.Delete(ctx); err != nil {
+			return fmt.Errorf("deleting containerd task: %w", err)
+		}
+	}
+
+	if err := container.Delete(ctx, containerd.WithSnapshotCleanup); err != nil {
+		return fmt.Errorf("deleting containerd container: %w", err)
+	}
+
+	session.State = types.SessionStopped
+	session.UpdatedAt = time.Now()
+
+	if r.auditor != nil {
+		r.auditor.Log(types.AuditEntry{
+			Timestamp: time.Now(),
+			SessionID: session.ID,
+			AgentName: session.AgentName,
+			EventType: "session_stopped",
+			Action:    types.ActionAllow,
+		})
+	}
+
+	return nil
+}
+
+// StopSessionWithSignal forwards sig to the task's init process and waits
+// up to timeout for it to exit before falling back to StopSession's
+// SIGKILL-and-delete teardown.
+func (r *ContainerdRuntime) StopSessionWithSignal(ctx context.Context, session *types.Session, sig string, timeout time.Duration) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	container, err := r.client.LoadContainer(r.ctx(), session.ContainerID)
+	if err == nil {
+		if task, taskErr := container.Task(r.ctx(), nil); taskErr == nil {
+			exitCh, waitErr := task.Wait(r.ctx())
+			if waitErr == nil {
+				if err := r.SignalContainer(ctx, session.ContainerID, sig); err != nil {
+					return fmt.Errorf("signaling container: %w", err)
+				}
+				select {
+				case <-exitCh:
+				case <-waitCtx.Done():
+					if r.auditor != nil {
+						r.auditor.Log(types.AuditEntry{
+							Timestamp: time.Now(),
+							SessionID: session.ID,
+							AgentName: session.AgentName,
+							EventType: "session_force_killed",
+							Action:    types.ActionAllow,
+							Reason:    fmt.Sprintf("task did not exit within %s of %s", timeout, sig),
+						})
+					}
+				}
+			}
+		}
+	}
+
+	return r.StopSession(ctx, session)
+}
+
+// ExecCommand runs cmd as an additional process in the task, capturing its
+// combined output the same way Engine.execInContainer does for Docker exec.
+func (r *ContainerdRuntime) ExecCommand(ctx context.Context, session *types.Session, cmd []string) (string, error) {
+	commandStr := strings.Join(cmd, " ")
+
+	if r.policy != nil {
+		result := r.policy.EvaluateCommand(ctx, commandStr)
+		if !result.Allowed {
+			return "", fmt.Errorf("policy blocked: %s (reason: %s)", commandStr, result.Reason)
+		}
+	}
+
+	ctx = r.ctx()
+
+	container, err := r.client.LoadContainer(ctx, session.ContainerID)
+	if err != nil {
+		return "", fmt.Errorf("loading containerd container: %w", err)
+	}
+
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("loading containerd task: %w", err)
+	}
+
+	spec, err := container.Spec(ctx)
+	if err != nil {
+		return "", fmt.Errorf("loading containerd spec: %w", err)
+	}
+
+	execProcess := *spec.Process
+	execProcess.Args = cmd
+	execProcess.Terminal = false
+
+	var out bytes.Buffer
+	execID := fmt.Sprintf("exec-%d", time.Now().UnixNano())
+	process, err := task.Exec(ctx, execID, &execProcess, cio.NewCreator(cio.WithStreams(nil, &out, &out)))
+	if err != nil {
+		return "", fmt.Errorf("creating containerd exec: %w", err)
+	}
+	defer process.Delete(ctx)
+
+	exitCh, err := process.Wait(ctx)
+	if err != nil {
+		return "", fmt.Errorf("waiting on containerd exec: %w", err)
+	}
+	if err := process.Start(ctx); err != nil {
+		return "", fmt.Errorf("starting containerd exec: %w", err)
+	}
+	<-exitCh
+
+	return out.String(), nil
+}
+
+// SignalContainer sends signal to the task's init process.
+func (r *ContainerdRuntime) SignalContainer(ctx context.Context, containerID, signal string) error {
+	ctx = r.ctx()
+
+	container, err := r.client.LoadContainer(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("loading containerd container: %w", err)
+	}
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("loading containerd task: %w", err)
+	}
+	sig, err := parseSignal(signal)
+	if err != nil {
+		return err
+	}
+	return task.Kill(ctx, sig)
+}
+
+// ListSessions enumerates containers in the claudeshield namespace.
+func (r *ContainerdRuntime) ListSessions(ctx context.Context) ([]*types.Session, error) {
+	ctx = r.ctx()
+
+	containers, err := r.client.Containers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing containerd containers: %w", err)
+	}
+
+	var sessions []*types.Session
+	for _, c := range containers {
+		info, err := c.Info(ctx)
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, &types.Session{
+			ID:          c.ID(),
+			ContainerID: c.ID(),
+			State:       types.SessionRunning,
+			CreatedAt:   info.CreatedAt,
+		})
+	}
+	return sessions, nil
+}
+
+// Close closes the containerd client connection.
+func (r *ContainerdRuntime) Close() error {
+	return r.client.Close()
+}
+
+// parseSignal maps the small set of signal names ClaudeShield actually
+// sends (see sandbox.SignalContainer callers) to their syscall values.
+func parseSignal(name string) (syscall.Signal, error) {
+	switch strings.ToUpper(strings.TrimPrefix(name, "SIG")) {
+	case "HUP":
+		return syscall.SIGHUP, nil
+	case "INT":
+		return syscall.SIGINT, nil
+	case "TERM":
+		return syscall.SIGTERM, nil
+	case "KILL":
+		return syscall.SIGKILL, nil
+	case "USR1":
+		return syscall.SIGUSR1, nil
+	case "USR2":
+		return syscall.SIGUSR2, nil
+	default:
+		return 0, fmt.Errorf("unsupported signal %q", name)
+	}
+}
+
+var _ Runtime = (*ContainerdRuntime)(nil)
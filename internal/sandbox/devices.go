@@ -0,0 +1,77 @@
+package sandbox
+
+import (
+	"fmt"
+
+	"github.com/MakazhanAlpamys/claudeshield/internal/policy"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+)
+
+// applyDevices validates each requested CDI device spec against the policy
+// engine's device allow-list (see policy.Engine.EvaluateDevice), resolves it
+// through the host's CDI registry, and folds the resulting device nodes,
+// mounts, and env into hostCfg/containerCfg. CDI hooks have no equivalent at
+// the Docker API level, so they're passed through as
+// CLAUDESHIELD_CDI_HOOK_* env vars for the policy shell wrapper to run
+// before handing off to the agent command.
+func applyDevices(devices []string, policyEngine *policy.Engine, hostCfg *container.HostConfig, containerCfg *container.Config) error {
+	if len(devices) == 0 {
+		return nil
+	}
+
+	registry := cdi.GetRegistry()
+	if err := registry.Refresh(); err != nil {
+		return fmt.Errorf("refreshing CDI registry: %w", err)
+	}
+
+	for _, spec := range devices {
+		if policyEngine != nil {
+			if result := policyEngine.EvaluateDevice(spec); !result.Allowed {
+				return fmt.Errorf("device %q blocked by policy: %s", spec, result.Reason)
+			}
+		}
+
+		device := registry.DeviceDB().GetDevice(spec)
+		if device == nil {
+			return fmt.Errorf("CDI device %q not found in registry", spec)
+		}
+
+		edits := device.ContainerEdits
+		for _, node := range edits.DeviceNodes {
+			hostCfg.Resources.Devices = append(hostCfg.Resources.Devices, container.DeviceMapping{
+				PathOnHost:        node.Path,
+				PathInContainer:   node.Path,
+				CgroupPermissions: "rwm",
+			})
+		}
+		for _, m := range edits.Mounts {
+			hostCfg.Mounts = append(hostCfg.Mounts, mount.Mount{
+				Type:     mount.TypeBind,
+				Source:   m.HostPath,
+				Target:   m.ContainerPath,
+				ReadOnly: containsOption(m.Options, "ro"),
+			})
+		}
+		containerCfg.Env = append(containerCfg.Env, edits.Env...)
+		for _, hook := range edits.Hooks {
+			containerCfg.Env = append(containerCfg.Env, fmt.Sprintf("CLAUDESHIELD_CDI_HOOK_%s=%s", hook.HookName, hook.Path))
+		}
+	}
+
+	return nil
+}
+
+// containsOption reports whether a CDI mount's Options (there's no
+// dedicated read-only field on the CDI Mount struct, unlike Docker's own
+// mount.Mount) carries the "ro" flag mount(8) and the OCI runtime both
+// recognize.
+func containsOption(options []string, want string) bool {
+	for _, o := range options {
+		if o == want {
+			return true
+		}
+	}
+	return false
+}
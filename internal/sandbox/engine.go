@@ -8,10 +8,13 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/MakazhanAlpamys/claudeshield/internal/audit"
+	"github.com/MakazhanAlpamys/claudeshield/internal/interceptor"
 	"github.com/MakazhanAlpamys/claudeshield/internal/policy"
+	"github.com/MakazhanAlpamys/claudeshield/internal/secrets/template"
 	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/image"
@@ -26,14 +29,51 @@ const (
 
 // Engine manages Docker-based sandbox containers.
 type Engine struct {
-	client  *client.Client
-	auditor *audit.Logger
-	policy  *policy.Engine
+	client       *client.Client
+	auditor      *audit.Logger
+	policy       *policy.Engine
+	interceptors []interceptor.Interceptor
+
+	// templateRunners tracks the live secret-template Runner for each
+	// session that has SecretTemplates configured, so StopSession can tear
+	// its background re-render loop down. See templates.go.
+	templateRunnersMu sync.Mutex
+	templateRunners   map[string]*template.Runner
+}
+
+// Option configures an Engine at construction time.
+type Option func(*Engine)
+
+// WithInterceptors appends interceptors (outermost first) to the chain
+// run around Engine operations, after the Recovery and PolicyGate
+// interceptors New wires up by default from auditor/policyEngine.
+func WithInterceptors(interceptors ...interceptor.Interceptor) Option {
+	return func(e *Engine) {
+		e.interceptors = append(e.interceptors, interceptors...)
+	}
 }
 
 // New creates a new sandbox engine connected to the local Docker daemon.
-func New(auditor *audit.Logger, policyEngine *policy.Engine) (*Engine, error) {
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+func New(auditor *audit.Logger, policyEngine *policy.Engine, opts ...Option) (*Engine, error) {
+	return newEngine(client.FromEnv, auditor, policyEngine, opts...)
+}
+
+// NewRootless connects to a user-level Docker/Podman socket
+// ($XDG_RUNTIME_DIR/docker.sock or .../podman/podman.sock) instead of the
+// system daemon, for hosts with no root-owned dockerd. When cfg.Rootless is
+// set, CreateSession drops CapAdd and checks that the current user has a
+// subuid/subgid range; it sets no per-container uid/gid remap — see
+// rootless.go.
+func NewRootless(auditor *audit.Logger, policyEngine *policy.Engine, opts ...Option) (*Engine, error) {
+	host, err := rootlessDockerHost()
+	if err != nil {
+		return nil, err
+	}
+	return newEngine(client.WithHost(host), auditor, policyEngine, opts...)
+}
+
+func newEngine(hostOpt client.Opt, auditor *audit.Logger, policyEngine *policy.Engine, opts ...Option) (*Engine, error) {
+	cli, err := client.NewClientWithOpts(hostOpt, client.WithAPIVersionNegotiation())
 	if err != nil {
 		return nil, fmt.Errorf("connecting to Docker: %w", err)
 	}
@@ -45,7 +85,20 @@ func New(auditor *audit.Logger, policyEngine *policy.Engine) (*Engine, error) {
 		return nil, fmt.Errorf("Docker is not running or not accessible: %w", err)
 	}
 
-	return &Engine{client: cli, auditor: auditor, policy: policyEngine}, nil
+	e := &Engine{
+		client:          cli,
+		auditor:         auditor,
+		policy:          policyEngine,
+		templateRunners: make(map[string]*template.Runner),
+		interceptors: []interceptor.Interceptor{
+			interceptor.Recovery(auditor),
+			interceptor.PolicyGate(policyEngine),
+		},
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e, nil
 }
 
 // Client returns the underlying Docker client (used by rollback manager).
@@ -56,6 +109,20 @@ func (e *Engine) Client() *client.Client {
 // CreateSession creates and starts a new sandbox container for the project.
 // If secrets are provided, they are injected as environment variables into the container.
 func (e *Engine) CreateSession(ctx context.Context, projectDir string, cfg types.SandboxConfig, agentName string, secrets map[string]string) (*types.Session, error) {
+	return e.createSession(ctx, projectDir, cfg, agentName, secrets, nil, nil)
+}
+
+// CreateSessionWithTemplates is CreateSession plus rendered secret
+// templates (see types.SecretsConfig.Templates): each is rendered via
+// resolve and bind-mounted read-only into the container, and re-rendered
+// in the background for as long as the session runs. Not part of the
+// Runtime interface — like rollback and cloning, template rendering is
+// Docker-specific for now.
+func (e *Engine) CreateSessionWithTemplates(ctx context.Context, projectDir string, cfg types.SandboxConfig, agentName string, secrets map[string]string, templates []types.SecretTemplate, resolve template.Resolver) (*types.Session, error) {
+	return e.createSession(ctx, projectDir, cfg, agentName, secrets, templates, resolve)
+}
+
+func (e *Engine) createSession(ctx context.Context, projectDir string, cfg types.SandboxConfig, agentName string, secrets map[string]string, templates []types.SecretTemplate, resolve template.Resolver) (*types.Session, error) {
 	sessionID := fmt.Sprintf("cs-%s-%d", agentName, time.Now().UnixMilli())
 
 	// Ensure absolute path for Docker mounts
@@ -69,6 +136,23 @@ func (e *Engine) CreateSession(ctx context.Context, projectDir string, cfg types
 		return nil, err
 	}
 
+	// Provisional session used only to resolve "${session.*}"/"${agent.*}"
+	// field refs. They are resolved once, before the container exists, so
+	// session.containerId always yields the session ID (the container name).
+	refSession := &types.Session{
+		ID:          sessionID,
+		ContainerID: sessionID,
+		ProjectDir:  projectDir,
+		AgentName:   agentName,
+		CreatedAt:   time.Now(),
+	}
+
+	resolvedReadOnly, err := resolveMountRefs(cfg.ReadOnly, refSession)
+	if err != nil {
+		return nil, fmt.Errorf("resolving read_only mounts: %w", err)
+	}
+	cfg.ReadOnly = resolvedReadOnly
+
 	mounts, err := e.buildMounts(projectDir, cfg)
 	if err != nil {
 		return nil, err
@@ -85,6 +169,29 @@ func (e *Engine) CreateSession(ctx context.Context, projectDir string, cfg types
 		}
 	}
 
+	// Render secret templates and mount them read-only, before the
+	// container exists — each bind mount's host source must already be on
+	// disk by the time ContainerCreate runs.
+	templateMounts, templateRunner, err := renderTemplateMounts(sessionID, templates, resolve)
+	if err != nil {
+		return nil, fmt.Errorf("rendering secret templates: %w", err)
+	}
+	if templateRunner != nil {
+		if err := templateRunner.Start(ctx); err != nil {
+			return nil, fmt.Errorf("rendering secret templates: %w", err)
+		}
+		// Stopped on every remaining error path below (and handed off to
+		// storeTemplateRunner on success) so a failed create/start doesn't
+		// leak the background re-render goroutine or its rendered secret
+		// files under os.TempDir().
+		defer func() {
+			if templateRunner != nil {
+				templateRunner.Stop()
+			}
+		}()
+	}
+	mounts = append(mounts, templateMounts...)
+
 	hostCfg := &container.HostConfig{
 		Mounts:     mounts,
 		AutoRemove: false,
@@ -113,12 +220,31 @@ func (e *Engine) CreateSession(ctx context.Context, projectDir string, cfg types
 		hostCfg.Runtime = "runsc"
 	}
 
+	if cfg.Rootless {
+		if err := applyRootless(hostCfg, projectDir); err != nil {
+			return nil, fmt.Errorf("configuring rootless mode: %w", err)
+		}
+	}
+
 	// Build environment variables from secrets
 	var envVars []string
 	for k, v := range secrets {
 		envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
 	}
 
+	resolvedEnv, err := resolveEnvRefs(cfg.Env, refSession)
+	if err != nil {
+		return nil, fmt.Errorf("resolving sandbox env refs: %w", err)
+	}
+	for k, v := range resolvedEnv {
+		envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	resolvedLabels, err := resolveLabelRefs(cfg.Labels, refSession)
+	if err != nil {
+		return nil, fmt.Errorf("resolving sandbox label refs: %w", err)
+	}
+
 	// Use policy shell wrapper if policy engine is configured
 	shellCmd := []string{"sleep", "infinity"}
 	if e.policy != nil {
@@ -126,14 +252,28 @@ func (e *Engine) CreateSession(ctx context.Context, projectDir string, cfg types
 		shellCmd = []string{"/usr/local/bin/claudeshield-shell"}
 	}
 
+	labels := make(map[string]string, len(resolvedLabels)+4)
+	for k, v := range resolvedLabels {
+		labels[k] = v
+	}
+	labels[ContainerLabel] = "true"
+	labels["claudeshield.session"] = sessionID
+	labels["claudeshield.agent"] = agentName
+	labels["claudeshield.project"] = projectDir
+	if len(secrets) > 0 {
+		// Recorded so CloneSession can strip these keys from the source
+		// container's env instead of copying stale secret values forward —
+		// a clone re-derives its own secrets.
+		secretKeys := make([]string, 0, len(secrets))
+		for k := range secrets {
+			secretKeys = append(secretKeys, k)
+		}
+		labels["claudeshield.secret_keys"] = strings.Join(secretKeys, ",")
+	}
+
 	containerCfg := &container.Config{
-		Image: SandboxImage,
-		Labels: map[string]string{
-			ContainerLabel:           "true",
-			"claudeshield.session":   sessionID,
-			"claudeshield.agent":     agentName,
-			"claudeshield.project":   projectDir,
-		},
+		Image:      SandboxImage,
+		Labels:     labels,
 		Env:        envVars,
 		Cmd:        shellCmd,
 		WorkingDir: "/workspace",
@@ -141,6 +281,22 @@ func (e *Engine) CreateSession(ctx context.Context, projectDir string, cfg types
 		OpenStdin:  true,
 	}
 
+	if err := applyDevices(cfg.Devices, e.policy, hostCfg, containerCfg); err != nil {
+		return nil, fmt.Errorf("attaching devices: %w", err)
+	}
+	for _, device := range cfg.Devices {
+		if e.auditor != nil {
+			e.auditor.Log(types.AuditEntry{
+				Timestamp: time.Now(),
+				SessionID: sessionID,
+				AgentName: agentName,
+				EventType: "device_attached",
+				Action:    types.ActionAllow,
+				Command:   device,
+			})
+		}
+	}
+
 	resp, err := e.client.ContainerCreate(ctx, containerCfg, hostCfg, nil, nil, sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("creating container: %w", err)
@@ -150,6 +306,16 @@ func (e *Engine) CreateSession(ctx context.Context, projectDir string, cfg types
 		return nil, fmt.Errorf("starting container: %w", err)
 	}
 
+	if templateRunner != nil {
+		templateRunner.ContainerID = resp.ID
+		templateRunner.SendSignal = e.SignalContainer
+		e.storeTemplateRunner(sessionID, templateRunner)
+		// Ownership has passed to e.templateRunners (torn down by
+		// StopSession/stopTemplateRunner); don't let the deferred Stop
+		// above undo that on the way out of this function.
+		templateRunner = nil
+	}
+
 	session := &types.Session{
 		ID:          sessionID,
 		ProjectDir:  projectDir,
@@ -186,6 +352,8 @@ func (e *Engine) StopSession(ctx context.Context, session *types.Session) error
 		return fmt.Errorf("removing container: %w", err)
 	}
 
+	e.stopTemplateRunner(session.ID)
+
 	session.State = types.SessionStopped
 	session.UpdatedAt = time.Now()
 
@@ -202,22 +370,70 @@ func (e *Engine) StopSession(ctx context.Context, session *types.Session) error
 	return nil
 }
 
-// ExecCommand runs a command inside the sandbox container, after policy check.
+// StopSessionWithSignal forwards sig to the container (a "docker kill
+// --signal" equivalent) and waits up to timeout for it to exit before
+// falling back to StopSession's SIGKILL-and-remove teardown. Mirrors
+// podman's sigproxy_common.go: Ctrl-C on "claudeshield start" should stop
+// the agent process cleanly instead of leaving an orphaned container.
+func (e *Engine) StopSessionWithSignal(ctx context.Context, session *types.Session, sig string, timeout time.Duration) error {
+	if err := e.SignalContainer(ctx, session.ContainerID, sig); err != nil {
+		return fmt.Errorf("signaling container: %w", err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	statusCh, errCh := e.client.ContainerWait(waitCtx, session.ContainerID, container.WaitConditionNotRunning)
+	select {
+	case <-statusCh:
+	case err := <-errCh:
+		if err != nil && waitCtx.Err() == nil {
+			return fmt.Errorf("waiting for container to exit: %w", err)
+		}
+	case <-waitCtx.Done():
+		if e.auditor != nil {
+			e.auditor.Log(types.AuditEntry{
+				Timestamp: time.Now(),
+				SessionID: session.ID,
+				AgentName: session.AgentName,
+				EventType: "session_force_killed",
+				Action:    types.ActionAllow,
+				Reason:    fmt.Sprintf("container did not exit within %s of %s", timeout, sig),
+			})
+		}
+	}
+
+	return e.StopSession(ctx, session)
+}
+
+// ExecCommand runs a command inside the sandbox container, after policy
+// check. The actual exec is run through the engine's interceptor chain
+// (Recovery and PolicyGate by default), with the command attached via
+// WithCommand so PolicyGate re-evaluates it as a second, independent gate —
+// on top of the direct EvaluateCommand check above, which is still what
+// produces the audit entry — so a panic from the Docker API client can't
+// take down the caller without at least an audit trail.
 func (e *Engine) ExecCommand(ctx context.Context, session *types.Session, cmd []string) (string, error) {
-	// Policy check before execution
 	commandStr := strings.Join(cmd, " ")
+
 	if e.policy != nil {
-		result := e.policy.EvaluateCommand(commandStr)
+		result := e.policy.EvaluateCommand(ctx, commandStr)
 
 		if e.auditor != nil {
 			entry := types.AuditEntry{
-				Timestamp: time.Now(),
-				SessionID: session.ID,
-				AgentName: session.AgentName,
-				EventType: "command_exec",
-				Command:   commandStr,
-				Action:    result.Action,
-				Reason:    result.Reason,
+				Timestamp:       time.Now(),
+				SessionID:       session.ID,
+				AgentName:       session.AgentName,
+				EventType:       "command_exec",
+				Command:         commandStr,
+				Action:          result.Action,
+				Reason:          result.Reason,
+				RequestID:       result.RequestID,
+				RunnerID:        result.RunnerID,
+				MatchedZones:    result.MatchedZones,
+				WouldBlock:      result.WouldBlock,
+				EnforcementMode: result.EnforcementMode,
+				AuditSeverity:   result.AuditSeverity,
 			}
 			if result.Rule != nil {
 				entry.RulePattern = result.Rule.Pattern
@@ -230,6 +446,19 @@ func (e *Engine) ExecCommand(ctx context.Context, session *types.Session, cmd []
 		}
 	}
 
+	res, err := interceptor.Run(interceptor.WithCommand(ctx, commandStr), interceptor.OpExec, func(ctx context.Context) (interceptor.Result, error) {
+		output, err := e.execInContainer(ctx, session, cmd)
+		return interceptor.Result{Value: output}, err
+	}, e.interceptors...)
+	if err != nil {
+		return "", err
+	}
+
+	output, _ := res.Value.(string)
+	return output, nil
+}
+
+func (e *Engine) execInContainer(ctx context.Context, session *types.Session, cmd []string) (string, error) {
 	execCfg := container.ExecOptions{
 		Cmd:          cmd,
 		AttachStdout: true,
@@ -256,6 +485,16 @@ func (e *Engine) ExecCommand(ctx context.Context, session *types.Session, cmd []
 	return string(output), nil
 }
 
+// SignalContainer sends a signal to the container's entrypoint process,
+// equivalent to "docker kill --signal". Used to notify an agent process of
+// a secret template re-render (e.g. SIGHUP/SIGUSR1).
+func (e *Engine) SignalContainer(ctx context.Context, containerID, signal string) error {
+	if err := e.client.ContainerKill(ctx, containerID, signal); err != nil {
+		return fmt.Errorf("signaling container %s with %s: %w", containerID, signal, err)
+	}
+	return nil
+}
+
 // ListSessions returns all active ClaudeShield containers.
 func (e *Engine) ListSessions(ctx context.Context) ([]*types.Session, error) {
 	containers, err := e.client.ContainerList(ctx, container.ListOptions{
@@ -291,6 +530,38 @@ func (e *Engine) ListSessions(ctx context.Context) ([]*types.Session, error) {
 	return sessions, nil
 }
 
+// InspectDetail carries the low-level container facts `claudeshield status
+// --session` surfaces that aren't part of types.Session itself, because
+// they come straight from the Docker daemon rather than ClaudeShield's own
+// bookkeeping.
+type InspectDetail struct {
+	CgroupParent string
+	Mounts       []string
+	// SecretKeys is the session's actual injected secret key names, read
+	// back from the claudeshield.secret_keys label createSession set —
+	// never the provider's backend config (cfg.Secrets.Options).
+	SecretKeys []string
+}
+
+// InspectSession fetches container-level detail for session directly from
+// Docker, for "status --session" to report alongside the policy/secrets/
+// audit context the caller already has from config.
+func (e *Engine) InspectSession(ctx context.Context, session *types.Session) (*InspectDetail, error) {
+	info, err := e.client.ContainerInspect(ctx, session.ContainerID)
+	if err != nil {
+		return nil, fmt.Errorf("inspecting container %s: %w", session.ContainerID, err)
+	}
+
+	detail := &InspectDetail{CgroupParent: info.HostConfig.CgroupParent}
+	for _, m := range info.Mounts {
+		detail.Mounts = append(detail.Mounts, fmt.Sprintf("%s:%s:%s", m.Source, m.Destination, m.Mode))
+	}
+	if keys := info.Config.Labels["claudeshield.secret_keys"]; keys != "" {
+		detail.SecretKeys = strings.Split(keys, ",")
+	}
+	return detail, nil
+}
+
 // Close closes the Docker client.
 func (e *Engine) Close() error {
 	return e.client.Close()
@@ -0,0 +1,269 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// The kube* types below are a deliberately small, hand-rolled subset of the
+// Kubernetes Pod/Secret/ConfigMap schemas — just enough fields to describe
+// what a ClaudeShield sandbox actually uses. Pulling in k8s.io/api for this
+// would be a lot of dependency weight for "print some YAML".
+
+type kubeMeta struct {
+	Name   string            `yaml:"name"`
+	Labels map[string]string `yaml:"labels,omitempty"`
+}
+
+type kubeSecretKeyRef struct {
+	Name string `yaml:"name"`
+	Key  string `yaml:"key"`
+}
+
+type kubeEnvVarSource struct {
+	SecretKeyRef kubeSecretKeyRef `yaml:"secretKeyRef"`
+}
+
+type kubeEnvVar struct {
+	Name      string            `yaml:"name"`
+	Value     string            `yaml:"value,omitempty"`
+	ValueFrom *kubeEnvVarSource `yaml:"valueFrom,omitempty"`
+}
+
+type kubeVolumeMount struct {
+	Name      string `yaml:"name"`
+	MountPath string `yaml:"mountPath"`
+	ReadOnly  bool   `yaml:"readOnly,omitempty"`
+}
+
+type kubeCapabilities struct {
+	Add  []string `yaml:"add,omitempty"`
+	Drop []string `yaml:"drop,omitempty"`
+}
+
+type kubeSecurityContext struct {
+	AllowPrivilegeEscalation *bool            `yaml:"allowPrivilegeEscalation,omitempty"`
+	Capabilities             kubeCapabilities `yaml:"capabilities"`
+}
+
+type kubeResources struct {
+	Limits map[string]string `yaml:"limits,omitempty"`
+}
+
+type kubeContainer struct {
+	Name            string              `yaml:"name"`
+	Image           string              `yaml:"image"`
+	Command         []string            `yaml:"command,omitempty"`
+	Env             []kubeEnvVar        `yaml:"env,omitempty"`
+	Resources       kubeResources       `yaml:"resources,omitempty"`
+	SecurityContext kubeSecurityContext `yaml:"securityContext"`
+	VolumeMounts    []kubeVolumeMount   `yaml:"volumeMounts,omitempty"`
+}
+
+type kubeHostPathVolumeSource struct {
+	Path string `yaml:"path"`
+}
+
+type kubeConfigMapVolumeSource struct {
+	Name string `yaml:"name"`
+}
+
+type kubeVolume struct {
+	Name      string                     `yaml:"name"`
+	HostPath  *kubeHostPathVolumeSource  `yaml:"hostPath,omitempty"`
+	ConfigMap *kubeConfigMapVolumeSource `yaml:"configMap,omitempty"`
+}
+
+type kubePodSpec struct {
+	RuntimeClassName string          `yaml:"runtimeClassName,omitempty"`
+	Containers       []kubeContainer `yaml:"containers"`
+	Volumes          []kubeVolume    `yaml:"volumes,omitempty"`
+}
+
+type kubePod struct {
+	APIVersion string      `yaml:"apiVersion"`
+	Kind       string      `yaml:"kind"`
+	Metadata   kubeMeta    `yaml:"metadata"`
+	Spec       kubePodSpec `yaml:"spec"`
+}
+
+type kubeSecret struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   kubeMeta          `yaml:"metadata"`
+	Type       string            `yaml:"type"`
+	StringData map[string]string `yaml:"stringData"`
+}
+
+type kubeConfigMap struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   kubeMeta          `yaml:"metadata"`
+	Data       map[string]string `yaml:"data"`
+}
+
+// GenerateKube serializes session's live container into a Pod+Secret+
+// ConfigMap YAML bundle — a Podman "kube generate"-style handoff from
+// running locally under ClaudeShield to running the same guardrails in a
+// cluster. Docker/claudeshield-internal labels are scrubbed; only
+// kubelet-meaningful fields (mounts, resource limits, capabilities, the
+// gVisor RuntimeClass, secret/config references) carry over.
+func (e *Engine) GenerateKube(ctx context.Context, session *types.Session) ([]byte, error) {
+	inspect, err := e.client.ContainerInspect(ctx, session.ContainerID)
+	if err != nil {
+		return nil, fmt.Errorf("inspecting container: %w", err)
+	}
+	if inspect.Config == nil || inspect.HostConfig == nil {
+		return nil, fmt.Errorf("container %s has no inspectable config", session.ContainerID)
+	}
+
+	podName := fmt.Sprintf("claudeshield-%s", session.AgentName)
+	secretName := podName + "-secrets"
+	configMapName := podName + "-policy"
+
+	secretKeys := map[string]bool{}
+	for _, k := range strings.Split(inspect.Config.Labels["claudeshield.secret_keys"], ",") {
+		if k != "" {
+			secretKeys[k] = true
+		}
+	}
+
+	var envVars []kubeEnvVar
+	secretData := map[string]string{}
+	for _, kv := range inspect.Config.Env {
+		parts := strings.SplitN(kv, "=", 2)
+		key := parts[0]
+		value := ""
+		if len(parts) == 2 {
+			value = parts[1]
+		}
+		if secretKeys[key] {
+			secretData[key] = value
+			envVars = append(envVars, kubeEnvVar{
+				Name:      key,
+				ValueFrom: &kubeEnvVarSource{SecretKeyRef: kubeSecretKeyRef{Name: secretName, Key: key}},
+			})
+			continue
+		}
+		envVars = append(envVars, kubeEnvVar{Name: key, Value: value})
+	}
+
+	var volumeMounts []kubeVolumeMount
+	var volumes []kubeVolume
+	var configMapData map[string]string
+
+	for i, m := range inspect.HostConfig.Mounts {
+		volName := fmt.Sprintf("mount-%d", i)
+		readOnly := m.ReadOnly
+
+		if m.Target == "/etc/claudeshield/policy.json" {
+			data, readErr := os.ReadFile(m.Source)
+			if readErr != nil {
+				return nil, fmt.Errorf("reading policy file for ConfigMap: %w", readErr)
+			}
+			volName = "policy"
+			volumeMounts = append(volumeMounts, kubeVolumeMount{Name: volName, MountPath: m.Target, ReadOnly: true})
+			volumes = append(volumes, kubeVolume{Name: volName, ConfigMap: &kubeConfigMapVolumeSource{Name: configMapName}})
+			configMapData = map[string]string{"policy.json": string(data)}
+			continue
+		}
+
+		volumeMounts = append(volumeMounts, kubeVolumeMount{Name: volName, MountPath: m.Target, ReadOnly: readOnly})
+		volumes = append(volumes, kubeVolume{Name: volName, HostPath: &kubeHostPathVolumeSource{Path: m.Source}})
+	}
+
+	limits := map[string]string{}
+	if inspect.HostConfig.Resources.Memory > 0 {
+		limits["memory"] = strconv.FormatInt(inspect.HostConfig.Resources.Memory, 10)
+	}
+	if inspect.HostConfig.Resources.NanoCPUs > 0 {
+		limits["cpu"] = strconv.FormatFloat(float64(inspect.HostConfig.Resources.NanoCPUs)/1e9, 'f', -1, 64)
+	}
+
+	allowPrivEsc := true
+	for _, opt := range inspect.HostConfig.SecurityOpt {
+		if opt == "no-new-privileges:true" {
+			allowPrivEsc = false
+		}
+	}
+
+	runtimeClass := ""
+	if inspect.HostConfig.Runtime == "runsc" {
+		runtimeClass = "gvisor"
+	}
+
+	pod := kubePod{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Metadata: kubeMeta{
+			Name: podName,
+			Labels: map[string]string{
+				"app":                   "claudeshield-agent",
+				"claudeshield.io/agent": session.AgentName,
+			},
+		},
+		Spec: kubePodSpec{
+			RuntimeClassName: runtimeClass,
+			Containers: []kubeContainer{{
+				Name:      "agent",
+				Image:     inspect.Config.Image,
+				Command:   inspect.Config.Cmd,
+				Env:       envVars,
+				Resources: kubeResources{Limits: limits},
+				SecurityContext: kubeSecurityContext{
+					AllowPrivilegeEscalation: &allowPrivEsc,
+					Capabilities: kubeCapabilities{
+						Add:  inspect.HostConfig.CapAdd,
+						Drop: inspect.HostConfig.CapDrop,
+					},
+				},
+				VolumeMounts: volumeMounts,
+			}},
+			Volumes: volumes,
+		},
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	defer enc.Close()
+
+	if err := enc.Encode(pod); err != nil {
+		return nil, fmt.Errorf("encoding Pod: %w", err)
+	}
+
+	if len(secretData) > 0 {
+		buf.WriteString("---\n")
+		secret := kubeSecret{
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Metadata:   kubeMeta{Name: secretName},
+			Type:       "Opaque",
+			StringData: secretData,
+		}
+		if err := enc.Encode(secret); err != nil {
+			return nil, fmt.Errorf("encoding Secret: %w", err)
+		}
+	}
+
+	if configMapData != nil {
+		buf.WriteString("---\n")
+		cm := kubeConfigMap{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Metadata:   kubeMeta{Name: configMapName},
+			Data:       configMapData,
+		}
+		if err := enc.Encode(cm); err != nil {
+			return nil, fmt.Errorf("encoding ConfigMap: %w", err)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
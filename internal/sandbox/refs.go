@@ -0,0 +1,94 @@
+package sandbox
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// fieldRefPattern matches downward-API-style field references such as
+// "${session.id}" or "${agent.name}".
+var fieldRefPattern = regexp.MustCompile(`\$\{([a-zA-Z]+\.[a-zA-Z]+)\}`)
+
+// fieldRefs is the whitelist of supported references, analogous to
+// Kubernetes' fieldRef for metadata.name/status.podIP. Anything not in
+// this map fails fast at config-resolution time instead of being passed
+// through to the container as a literal "${...}".
+var fieldRefs = map[string]func(s *types.Session) string{
+	"session.id": func(s *types.Session) string { return s.ID },
+	// session.containerId resolves to the session ID, since refs are
+	// resolved before the container exists and that's also the name
+	// ClaudeShield requests from Docker; the daemon-assigned ID is never
+	// substituted.
+	"session.containerId": func(s *types.Session) string { return s.ContainerID },
+	"session.worktreeDir": func(s *types.Session) string { return s.WorktreeDir },
+	"session.projectDir":  func(s *types.Session) string { return s.ProjectDir },
+	"session.startedAt":   func(s *types.Session) string { return s.CreatedAt.Format(time.RFC3339) },
+	"agent.name":          func(s *types.Session) string { return s.AgentName },
+}
+
+// resolveRefs expands every "${...}" field reference in s against
+// session. It returns an error on the first unknown reference so a typo
+// in a user's config fails loudly rather than leaking a literal
+// placeholder into the sandbox.
+func resolveRefs(s string, session *types.Session) (string, error) {
+	var resolveErr error
+	result := fieldRefPattern.ReplaceAllStringFunc(s, func(match string) string {
+		if resolveErr != nil {
+			return match
+		}
+		name := match[2 : len(match)-1]
+		ref, ok := fieldRefs[name]
+		if !ok {
+			resolveErr = fmt.Errorf("unknown field reference %q", match)
+			return match
+		}
+		return ref(session)
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	return result, nil
+}
+
+// resolveEnvRefs expands field references in a map of env values.
+func resolveEnvRefs(env map[string]string, session *types.Session) (map[string]string, error) {
+	out := make(map[string]string, len(env))
+	for k, v := range env {
+		resolved, err := resolveRefs(v, session)
+		if err != nil {
+			return nil, fmt.Errorf("env[%s]: %w", k, err)
+		}
+		out[k] = resolved
+	}
+	return out, nil
+}
+
+// resolveLabelRefs expands field references in a map of label values.
+func resolveLabelRefs(labels map[string]string, session *types.Session) (map[string]string, error) {
+	out := make(map[string]string, len(labels))
+	for k, v := range labels {
+		resolved, err := resolveRefs(v, session)
+		if err != nil {
+			return nil, fmt.Errorf("labels[%s]: %w", k, err)
+		}
+		out[k] = resolved
+	}
+	return out, nil
+}
+
+// resolveMountRefs expands field references in a list of bind-mount
+// source paths.
+func resolveMountRefs(paths []string, session *types.Session) ([]string, error) {
+	out := make([]string, len(paths))
+	for i, p := range paths {
+		resolved, err := resolveRefs(p, session)
+		if err != nil {
+			return nil, fmt.Errorf("read_only[%d]: %w", i, err)
+		}
+		out[i] = resolved
+	}
+	return out, nil
+}
@@ -0,0 +1,64 @@
+package sandbox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+func testSession() *types.Session {
+	return &types.Session{
+		ID:          "cs-dev-123",
+		ContainerID: "cs-dev-123",
+		AgentName:   "dev",
+		ProjectDir:  "/home/user/project",
+		WorktreeDir: "/home/user/project/.claudeshield/worktrees/dev",
+		CreatedAt:   time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestResolveRefs_KnownFields(t *testing.T) {
+	s := testSession()
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"${session.id}", "cs-dev-123"},
+		{"${agent.name}", "dev"},
+		{"${session.projectDir}/data", "/home/user/project/data"},
+		{"prefix-${session.worktreeDir}", "prefix-" + s.WorktreeDir},
+		{"${session.startedAt}", "2026-01-02T15:04:05Z"},
+		{"no refs here", "no refs here"},
+	}
+
+	for _, tt := range tests {
+		got, err := resolveRefs(tt.in, s)
+		if err != nil {
+			t.Errorf("resolveRefs(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("resolveRefs(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestResolveRefs_UnknownFieldFailsFast(t *testing.T) {
+	_, err := resolveRefs("${session.bogus}", testSession())
+	if err == nil {
+		t.Error("expected error for unknown field reference")
+	}
+}
+
+func TestResolveEnvRefs(t *testing.T) {
+	env := map[string]string{"SESSION_ID": "${session.id}"}
+	resolved, err := resolveEnvRefs(env, testSession())
+	if err != nil {
+		t.Fatalf("resolveEnvRefs: %v", err)
+	}
+	if resolved["SESSION_ID"] != "cs-dev-123" {
+		t.Errorf("SESSION_ID = %q, want %q", resolved["SESSION_ID"], "cs-dev-123")
+	}
+}
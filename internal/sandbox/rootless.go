@@ -0,0 +1,138 @@
+package sandbox
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// subIDRange is a contiguous uid or gid range granted to a host user via an
+// /etc/subuid or /etc/subgid entry, e.g. "alice:100000:65536".
+type subIDRange struct {
+	Start  int
+	Length int
+}
+
+// lookupSubIDRange reads path (/etc/subuid or /etc/subgid) for username's
+// granted range.
+func lookupSubIDRange(path, username string) (subIDRange, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return subIDRange{}, fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Split(strings.TrimSpace(scanner.Text()), ":")
+		if len(fields) != 3 || fields[0] != username {
+			continue
+		}
+		start, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		length, err := strconv.Atoi(fields[2])
+		if err != nil {
+			continue
+		}
+		return subIDRange{Start: start, Length: length}, nil
+	}
+	return subIDRange{}, fmt.Errorf("no entry for user %q in %s — grant one with 'usermod --add-subuids/--add-subgids'", username, path)
+}
+
+// rootlessUserNamespace reads the current user's uid and gid ranges from
+// /etc/subuid and /etc/subgid, the ranges a Podman-rootless style remap
+// maps the container's root into. It only looks them up; applyRootless uses
+// it to check that they exist.
+func rootlessUserNamespace() (uidRange, gidRange subIDRange, err error) {
+	u, err := user.Current()
+	if err != nil {
+		return subIDRange{}, subIDRange{}, fmt.Errorf("looking up current user: %w", err)
+	}
+
+	uidRange, err = lookupSubIDRange("/etc/subuid", u.Username)
+	if err != nil {
+		return subIDRange{}, subIDRange{}, fmt.Errorf("rootless mode requires a subuid range: %w", err)
+	}
+	gidRange, err = lookupSubIDRange("/etc/subgid", u.Username)
+	if err != nil {
+		return subIDRange{}, subIDRange{}, fmt.Errorf("rootless mode requires a subgid range: %w", err)
+	}
+	return uidRange, gidRange, nil
+}
+
+// rootlessDockerHost locates a user-level Docker/Podman socket, preferring
+// a rootless dockerd over Podman's Docker-compatible socket.
+func rootlessDockerHost() (string, error) {
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = fmt.Sprintf("/run/user/%d", os.Getuid())
+	}
+
+	candidates := []string{
+		filepath.Join(runtimeDir, "docker.sock"),
+		filepath.Join(runtimeDir, "podman", "podman.sock"),
+	}
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return "unix://" + path, nil
+		}
+	}
+	return "", fmt.Errorf("rootless mode: no user-level Docker or Podman socket found under %s (looked for docker.sock, podman/podman.sock)", runtimeDir)
+}
+
+// applyRootless adjusts hostCfg for Podman-style rootless execution. Caps
+// meaningful only to a privileged daemon don't mean anything inside a user
+// namespace, so CapAdd is dropped entirely.
+//
+// Per-container uid/gid remapping is NOT configurable through the Docker
+// API: container.HostConfig.UsernsMode.Valid() only accepts "" or "host",
+// so pinning it to an arbitrary "uid:gid" string (as an earlier version of
+// this function did) is rejected by the daemon, or silently ignored by
+// API versions that don't validate it — either way no remapping actually
+// happens. Real uid/gid remapping is configured daemon-wide via dockerd's
+// --userns-remap flag, which must point at the same subuid/subgid range
+// rootlessUserNamespace reads here; that's out of band from anything a
+// single `claudeshield start` invocation can set on one container. This
+// function still requires a valid subuid/subgid range to exist (and the
+// project dir to be chown-compatible with it) so rootless mode fails
+// fast with a clear error instead of silently running unremapped.
+func applyRootless(hostCfg *container.HostConfig, projectDir string) error {
+	if _, _, err := rootlessUserNamespace(); err != nil {
+		return err
+	}
+
+	if err := validateChownCompatible(projectDir); err != nil {
+		return err
+	}
+
+	hostCfg.CapAdd = nil
+	return nil
+}
+
+// validateChownCompatible checks that projectDir is owned by the invoking
+// user, since a mismatched owner (e.g. left root-owned by a prior
+// non-rootless run) can't be remapped into the subuid range from inside the
+// unprivileged container.
+func validateChownCompatible(projectDir string) error {
+	info, err := os.Stat(projectDir)
+	if err != nil {
+		return fmt.Errorf("checking project dir for rootless compatibility: %w", err)
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	if int(stat.Uid) != os.Getuid() {
+		return fmt.Errorf("project dir %s is not owned by the current user (uid %d); rootless mode can't remap it into the subuid range", projectDir, os.Getuid())
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package sandbox
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSubIDFile(t *testing.T, dir, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatalf("writing %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLookupSubIDRange_Found(t *testing.T) {
+	dir := t.TempDir()
+	path := writeSubIDFile(t, dir, "subuid", "root:0:65536\nalice:100000:65536\n")
+
+	r, err := lookupSubIDRange(path, "alice")
+	if err != nil {
+		t.Fatalf("lookupSubIDRange: %v", err)
+	}
+	if r.Start != 100000 || r.Length != 65536 {
+		t.Errorf("got %+v, want start=100000 length=65536", r)
+	}
+}
+
+func TestLookupSubIDRange_Missing(t *testing.T) {
+	dir := t.TempDir()
+	path := writeSubIDFile(t, dir, "subuid", "root:0:65536\n")
+
+	if _, err := lookupSubIDRange(path, "alice"); err == nil {
+		t.Error("expected an error for a user with no subuid entry")
+	}
+}
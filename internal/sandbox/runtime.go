@@ -0,0 +1,53 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MakazhanAlpamys/claudeshield/internal/audit"
+	"github.com/MakazhanAlpamys/claudeshield/internal/policy"
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// Runtime is the OCI backend Engine-level code runs against. Docker is the
+// original (and still default) implementation; ContainerdRuntime talks to
+// containerd directly so hosts without a Docker daemon — or that want to
+// run gVisor/Kata shims containerd already knows how to drive — don't need
+// one installed just for ClaudeShield.
+//
+// rollback.Manager is NOT expressed in terms of Runtime: its CRIU and
+// ContainerCommit paths are Docker-API-specific (checkpoint/commit have no
+// containerd equivalent wired up yet), so it keeps taking a *client.Client
+// directly via Engine.Client().
+type Runtime interface {
+	CreateSession(ctx context.Context, projectDir string, cfg types.SandboxConfig, agentName string, secrets map[string]string) (*types.Session, error)
+	StopSession(ctx context.Context, session *types.Session) error
+	// StopSessionWithSignal forwards sig to the container and waits up to
+	// timeout for it to exit on its own before falling back to StopSession's
+	// hard teardown. Used by the "stop --signal" flag and sandbox.SignalProxy.
+	StopSessionWithSignal(ctx context.Context, session *types.Session, sig string, timeout time.Duration) error
+	ExecCommand(ctx context.Context, session *types.Session, cmd []string) (string, error)
+	ListSessions(ctx context.Context) ([]*types.Session, error)
+	SignalContainer(ctx context.Context, containerID, signal string) error
+	Close() error
+}
+
+var _ Runtime = (*Engine)(nil)
+
+// NewRuntime builds the Runtime backend named by kind ("docker", "containerd",
+// or "podman" — typically cfg.Runtime from .claudeshield.yaml). An empty kind
+// defaults to "docker".
+func NewRuntime(kind string, auditor *audit.Logger, policyEngine *policy.Engine) (Runtime, error) {
+	switch kind {
+	case "", "docker", "podman":
+		// podman's Docker-compatible API means the same client works against
+		// it once DOCKER_HOST points at podman.sock (see chunk1-3's rootless
+		// socket wiring).
+		return New(auditor, policyEngine)
+	case "containerd":
+		return NewContainerdRuntime(auditor, policyEngine)
+	default:
+		return nil, fmt.Errorf("unknown runtime %q (want docker, containerd, or podman)", kind)
+	}
+}
@@ -0,0 +1,110 @@
+package sandbox
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// DefaultStopTimeout is how long a graceful stop waits for the container to
+// exit on its own before escalating to SIGKILL, when SandboxConfig.StopTimeout
+// is unset.
+const DefaultStopTimeout = 10 * time.Second
+
+// SignalProxy forwards host signals into the sandbox container for as long
+// as a session is attached, mirroring podman's sigproxy_common.go: SIGINT
+// and SIGTERM trigger a graceful StopSessionWithSignal (escalating to
+// SIGKILL after Timeout), while SIGHUP and SIGUSR1 are just relayed so an
+// agent process can use them for its own purposes (e.g. a checkpoint
+// trigger) without ending the session.
+type SignalProxy struct {
+	runtime Runtime
+	session *types.Session
+	timeout time.Duration
+
+	sigCh   chan os.Signal
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// NewSignalProxy builds a SignalProxy for session. A zero timeout falls
+// back to DefaultStopTimeout.
+func NewSignalProxy(runtime Runtime, session *types.Session, timeout time.Duration) *SignalProxy {
+	if timeout <= 0 {
+		timeout = DefaultStopTimeout
+	}
+	return &SignalProxy{
+		runtime: runtime,
+		session: session,
+		timeout: timeout,
+		sigCh:   make(chan os.Signal, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Start begins listening for SIGINT, SIGTERM, SIGHUP, and SIGUSR1 in the
+// background. Stop, a graceful container exit via StopSessionWithSignal,
+// or ctx's cancellation all end the proxy.
+func (p *SignalProxy) Start(ctx context.Context) {
+	signal.Notify(p.sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGUSR1)
+
+	go func() {
+		defer signal.Stop(p.sigCh)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.done:
+				return
+			case sig := <-p.sigCh:
+				switch sig {
+				case syscall.SIGINT, syscall.SIGTERM:
+					_ = p.runtime.StopSessionWithSignal(context.Background(), p.session, dockerSignalName(sig), p.timeout)
+					close(p.stopped)
+					return
+				default:
+					_ = p.runtime.SignalContainer(context.Background(), p.session.ContainerID, dockerSignalName(sig))
+				}
+			}
+		}
+	}()
+}
+
+// Stop ends the proxy without touching the container, e.g. once the
+// session has already been torn down through a normal "claudeshield stop".
+func (p *SignalProxy) Stop() {
+	close(p.done)
+}
+
+// Wait blocks until SIGINT/SIGTERM has driven the session through a
+// graceful stop, or until Stop is called directly. Lets "claudeshield
+// start" stay in the foreground so Ctrl-C has something to catch.
+func (p *SignalProxy) Wait() {
+	select {
+	case <-p.stopped:
+	case <-p.done:
+	}
+}
+
+// dockerSignalName maps a host os.Signal to the name Engine.SignalContainer
+// (ContainerKill) and ContainerdRuntime.SignalContainer (parseSignal) both
+// accept.
+func dockerSignalName(sig os.Signal) string {
+	switch sig {
+	case syscall.SIGINT:
+		return "SIGINT"
+	case syscall.SIGTERM:
+		return "SIGTERM"
+	case syscall.SIGHUP:
+		return "SIGHUP"
+	case syscall.SIGUSR1:
+		return "SIGUSR1"
+	default:
+		return "SIGTERM"
+	}
+}
@@ -0,0 +1,70 @@
+package sandbox
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/MakazhanAlpamys/claudeshield/internal/secrets/template"
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+	"github.com/docker/docker/api/types/mount"
+)
+
+// renderTemplateMounts builds one bind mount per SecretTemplate, rendering
+// its source into a per-session directory under os.TempDir() — intended to
+// sit on a tmpfs (e.g. /tmp or /dev/shm on most Linux hosts) so rendered
+// secrets never touch persistent disk. The returned Runner has not been
+// started yet: the caller renders once (Start) before the container is
+// created, since the bind source must already exist on disk.
+func renderTemplateMounts(sessionID string, templates []types.SecretTemplate, resolve template.Resolver) ([]mount.Mount, *template.Runner, error) {
+	if len(templates) == 0 {
+		return nil, nil, nil
+	}
+	if resolve == nil {
+		return nil, nil, fmt.Errorf("secret templates configured but no template resolver was provided")
+	}
+
+	renderDir := filepath.Join(os.TempDir(), "claudeshield-secrets", sessionID)
+
+	specs := make([]template.Spec, 0, len(templates))
+	mounts := make([]mount.Mount, 0, len(templates))
+	for i, t := range templates {
+		hostDest := filepath.Join(renderDir, fmt.Sprintf("%d-%s", i, filepath.Base(t.Dest)))
+		mode := os.FileMode(0600)
+		if t.Mode != 0 {
+			mode = os.FileMode(t.Mode)
+		}
+
+		specs = append(specs, template.Spec{
+			Source: t.Src,
+			Dest:   hostDest,
+			Mode:   mode,
+			Signal: t.Signal,
+		})
+		mounts = append(mounts, mount.Mount{
+			Type:     mount.TypeBind,
+			Source:   hostDest,
+			Target:   t.Dest,
+			ReadOnly: true,
+		})
+	}
+
+	return mounts, template.NewRunner(specs, 0, resolve), nil
+}
+
+// storeTemplateRunner records runner against sessionID so StopSession can
+// tear it down; a session with no templates configured never has an entry.
+func (e *Engine) storeTemplateRunner(sessionID string, runner *template.Runner) {
+	e.templateRunnersMu.Lock()
+	defer e.templateRunnersMu.Unlock()
+	e.templateRunners[sessionID] = runner
+}
+
+func (e *Engine) stopTemplateRunner(sessionID string) {
+	e.templateRunnersMu.Lock()
+	defer e.templateRunnersMu.Unlock()
+	if runner, ok := e.templateRunners[sessionID]; ok {
+		runner.Stop()
+		delete(e.templateRunners, sessionID)
+	}
+}
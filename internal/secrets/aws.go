@@ -0,0 +1,85 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// AWSSecretsManagerProvider loads secrets from AWS Secrets Manager. It
+// builds its client from the standard AWS credential chain (env vars,
+// shared config, instance/task role), optionally pinned to a region and
+// assumed into a role, so it behaves the same way other AWS-aware
+// ClaudeShield tooling would expect.
+type AWSSecretsManagerProvider struct {
+	region  string
+	roleARN string
+	client  *secretsmanager.Client
+}
+
+func (p *AWSSecretsManagerProvider) Name() string { return "aws-secretsmanager" }
+
+// Configure reads region and role_arn from opts. Neither is required: an
+// empty region falls back to the SDK's own resolution (AWS_REGION,
+// shared config), and an empty role_arn uses the chain's base identity
+// directly instead of assuming a role.
+func (p *AWSSecretsManagerProvider) Configure(opts map[string]string) error {
+	p.region = opts["region"]
+	p.roleARN = opts["role_arn"]
+
+	ctx := context.Background()
+	loadOpts := []func(*awsconfig.LoadOptions) error{}
+	if p.region != "" {
+		loadOpts = append(loadOpts, awsconfig.WithRegion(p.region))
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, loadOpts...)
+	if err != nil {
+		return fmt.Errorf("aws-secretsmanager: loading AWS config: %w", err)
+	}
+
+	if p.roleARN != "" {
+		cfg.Credentials = stsAssumeRoleCredentials(cfg, p.roleARN)
+	}
+
+	p.client = secretsmanager.NewFromConfig(cfg)
+	return nil
+}
+
+func (p *AWSSecretsManagerProvider) Available() bool {
+	return p.client != nil
+}
+
+func (p *AWSSecretsManagerProvider) Load(keys []string) (map[string]string, error) {
+	ctx := context.Background()
+	result := make(map[string]string, len(keys))
+
+	for _, key := range keys {
+		out, err := p.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+			SecretId: aws.String(key),
+		})
+		if err != nil {
+			return result, fmt.Errorf("aws-secretsmanager: failed to read %q: %w", key, err)
+		}
+		if out.SecretString != nil {
+			result[key] = *out.SecretString
+			continue
+		}
+		result[key] = string(out.SecretBinary)
+	}
+
+	return result, nil
+}
+
+// stsAssumeRoleCredentials wraps base's credentials so every signed request
+// assumes roleARN first, refreshing automatically as the assumed session
+// nears expiry.
+func stsAssumeRoleCredentials(base aws.Config, roleARN string) aws.CredentialsProvider {
+	stsClient := sts.NewFromConfig(base)
+	return aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(stsClient, roleARN))
+}
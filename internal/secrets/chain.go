@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"fmt"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// ChainProvider tries a fixed list of providers in order, the same
+// fallback pattern used by the AWS SDK's credential chain and
+// HashiCorp's own Vault Agent auto-auth: the first provider whose
+// Available() reports true handles Load, so a project can configure
+// e.g. "vault then env" and degrade gracefully when Vault isn't
+// configured instead of failing outright. Availability is each provider's
+// own Available() check (for Vault: an address and credentials are set;
+// the server is not contacted), and a Load error from the chosen provider
+// is returned as is; the next provider is not tried.
+type ChainProvider struct {
+	name      string
+	providers []types.SecretProvider
+}
+
+// NewChainProvider builds a ChainProvider named name that tries providers
+// in the given order.
+func NewChainProvider(name string, providers ...types.SecretProvider) *ChainProvider {
+	return &ChainProvider{name: name, providers: providers}
+}
+
+func (p *ChainProvider) Name() string { return p.name }
+
+// Available reports whether any provider in the chain is available.
+func (p *ChainProvider) Available() bool {
+	_, ok := p.firstAvailable()
+	return ok
+}
+
+// Configure forwards opts to every chained provider that accepts
+// configuration, so a single "secrets.options" block can carry settings
+// for more than one backend (e.g. both vault.addr and aws.region). A
+// chained provider whose Configure fails is left unconfigured, so it
+// reports itself unavailable and the chain moves past it.
+func (p *ChainProvider) Configure(opts map[string]string) error {
+	for _, provider := range p.providers {
+		if cp, ok := provider.(ConfigurableProvider); ok {
+			_ = cp.Configure(opts)
+		}
+	}
+	return nil
+}
+
+func (p *ChainProvider) Load(keys []string) (map[string]string, error) {
+	provider, ok := p.firstAvailable()
+	if !ok {
+		return nil, fmt.Errorf("%s: no chained provider is available", p.name)
+	}
+	return provider.Load(keys)
+}
+
+func (p *ChainProvider) firstAvailable() (types.SecretProvider, bool) {
+	for _, provider := range p.providers {
+		if provider.Available() {
+			return provider, true
+		}
+	}
+	return nil, false
+}
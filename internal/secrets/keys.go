@@ -0,0 +1,30 @@
+package secrets
+
+import (
+	"github.com/MakazhanAlpamys/claudeshield/internal/secrets/template"
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// KeyNames returns the real secret key names cfg's templates reference
+// (the "secret:" refs inside each template's source), deduped across
+// templates. cfg.Options is provider backend configuration (a Vault
+// address, a static token, an AWS region) and never holds secret names, so
+// callers that need a key list to pass to SecretProvider.Load must use this
+// instead of iterating cfg.Options.
+func KeyNames(cfg types.SecretsConfig) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, t := range cfg.Templates {
+		refs, err := template.SecretRefs(t.Src)
+		if err != nil {
+			continue
+		}
+		for _, k := range refs {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,57 @@
+package secrets
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// pluginRequest and pluginResponse define the JSON-over-stdio wire
+// protocol spoken between claudeshield and an out-of-process secret
+// provider plugin (a "claudeshield-secrets-<name>" binary).
+type pluginRequest struct {
+	Op   string   `json:"op"`
+	Keys []string `json:"keys,omitempty"`
+}
+
+type pluginResponse struct {
+	Available bool              `json:"available,omitempty"`
+	Values    map[string]string `json:"values,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
+
+// ServePlugin runs impl as a claudeshield-secrets-* plugin: it reads one
+// JSON request from stdin ({"op":"available"} or {"op":"load","keys":[...]}),
+// dispatches it to impl, writes one JSON response to stdout, and returns.
+// Plugin authors call this as the entire body of main().
+func ServePlugin(impl types.SecretProvider) error {
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	if !scanner.Scan() {
+		return fmt.Errorf("no request on stdin")
+	}
+
+	var req pluginRequest
+	if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+		return fmt.Errorf("parsing plugin request: %w", err)
+	}
+
+	var resp pluginResponse
+	switch req.Op {
+	case "available":
+		resp.Available = impl.Available()
+	case "load":
+		values, err := impl.Load(req.Keys)
+		resp.Values = values
+		if err != nil {
+			resp.Error = err.Error()
+		}
+	default:
+		resp.Error = fmt.Sprintf("unknown op %q", req.Op)
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(resp)
+}
@@ -0,0 +1,55 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+)
+
+type stubProvider struct {
+	available bool
+	values    map[string]string
+}
+
+func (s *stubProvider) Name() string      { return "stub" }
+func (s *stubProvider) Available() bool   { return s.available }
+func (s *stubProvider) Load(keys []string) (map[string]string, error) {
+	return s.values, nil
+}
+
+func TestServePlugin_Load(t *testing.T) {
+	req := pluginRequest{Op: "load", Keys: []string{"API_KEY"}}
+	data, _ := json.Marshal(req)
+
+	r, w, _ := os.Pipe()
+	w.Write(append(data, '\n'))
+	w.Close()
+
+	origStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = origStdin }()
+
+	outR, outW, _ := os.Pipe()
+	origStdout := os.Stdout
+	os.Stdout = outW
+	defer func() { os.Stdout = origStdout }()
+
+	impl := &stubProvider{available: true, values: map[string]string{"API_KEY": "secret"}}
+	if err := ServePlugin(impl); err != nil {
+		t.Fatalf("ServePlugin: %v", err)
+	}
+	outW.Close()
+
+	var buf bytes.Buffer
+	io.Copy(&buf, outR)
+
+	var resp pluginResponse
+	if err := json.Unmarshal(buf.Bytes(), &resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if resp.Values["API_KEY"] != "secret" {
+		t.Errorf("Values[API_KEY] = %q, want secret", resp.Values["API_KEY"])
+	}
+}
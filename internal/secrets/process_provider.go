@@ -0,0 +1,107 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/MakazhanAlpamys/claudeshield/internal/audit"
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// pluginPrefix is the filename prefix DiscoverPlugins looks for, so third
+// parties can ship providers as standalone binaries without patching the
+// core registry.
+const pluginPrefix = "claudeshield-secrets-"
+
+// ProcessProvider wraps an out-of-process secret provider binary
+// discovered on $PATH or under ~/.claudeshield/plugins/, speaking the
+// JSON-over-stdio protocol implemented by secrets.ServePlugin.
+type ProcessProvider struct {
+	name string
+	path string
+}
+
+func (p *ProcessProvider) Name() string { return p.name }
+
+func (p *ProcessProvider) Available() bool {
+	resp, err := p.call(pluginRequest{Op: "available"})
+	return err == nil && resp.Available
+}
+
+func (p *ProcessProvider) Load(keys []string) (map[string]string, error) {
+	resp, err := p.call(pluginRequest{Op: "load", Keys: keys})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", p.name, err)
+	}
+	if resp.Error != "" {
+		return resp.Values, fmt.Errorf("%s: %s", p.name, resp.Error)
+	}
+	return resp.Values, nil
+}
+
+func (p *ProcessProvider) call(req pluginRequest) (*pluginResponse, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(p.path)
+	cmd.Stdin = bytes.NewReader(append(data, '\n'))
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("running plugin: %w", err)
+	}
+
+	var resp pluginResponse
+	if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &resp); err != nil {
+		return nil, fmt.Errorf("parsing plugin response: %w", err)
+	}
+	return &resp, nil
+}
+
+// DiscoverPlugins scans $PATH and ~/.claudeshield/plugins for
+// claudeshield-secrets-* binaries and registers a ProcessProvider for
+// each. Discovery itself never fails the caller; a plugin that later
+// fails its Available() probe is simply reported unhealthy (by
+// "claudeshield secrets doctor") rather than treated as fatal, and that
+// failure is logged via auditor when one is supplied.
+func (r *Registry) DiscoverPlugins(auditor *audit.Logger) {
+	for _, dir := range pluginDirs() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasPrefix(e.Name(), pluginPrefix) {
+				continue
+			}
+
+			name := strings.TrimPrefix(e.Name(), pluginPrefix)
+			provider := &ProcessProvider{name: name, path: filepath.Join(dir, e.Name())}
+			r.Register(provider)
+
+			if !provider.Available() && auditor != nil {
+				auditor.Log(types.AuditEntry{
+					EventType: "secret_plugin_unavailable",
+					Reason:    fmt.Sprintf("plugin %q failed its available probe", name),
+					Action:    types.ActionAudit,
+				})
+			}
+		}
+	}
+}
+
+func pluginDirs() []string {
+	dirs := filepath.SplitList(os.Getenv("PATH"))
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".claudeshield", "plugins"))
+	}
+	return dirs
+}
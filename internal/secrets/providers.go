@@ -1,12 +1,28 @@
 package secrets
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"os"
 	"os/exec"
 	"strings"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
 )
 
+// ConfigurableProvider is implemented by providers whose behavior depends
+// on per-project settings (a Vault address, an AWS region, an assumed
+// role) rather than pure environment/CLI discovery. Registry.LoadSecrets
+// calls Configure with cfg.Options before Available/Load, so a provider
+// can pick up project-specific settings without widening
+// types.SecretProvider for every provider that doesn't need them.
+type ConfigurableProvider interface {
+	types.SecretProvider
+	Configure(opts map[string]string) error
+}
+
 // OnePasswordProvider loads secrets from 1Password CLI (op).
 type OnePasswordProvider struct{}
 
@@ -74,21 +90,54 @@ func (p *OnePasswordEnvProvider) Load(keys []string) (map[string]string, error)
 	return result, nil
 }
 
-// VaultProvider loads secrets from HashiCorp Vault.
-type VaultProvider struct{}
+// VaultProvider loads secrets from HashiCorp Vault's KV v2 API directly
+// over HTTP, rather than shelling out to the vault CLI, so it works in
+// sandboxes that don't ship that binary. It authenticates with a static
+// token if one is configured, or logs in via AppRole otherwise.
+type VaultProvider struct {
+	addr     string
+	mount    string
+	token    string
+	roleID   string
+	secretID string
+	client   *http.Client
+}
 
 func (p *VaultProvider) Name() string { return "vault" }
 
+// Configure reads addr, mount, token, role_id, and secret_id from opts,
+// falling back to VAULT_ADDR, VAULT_TOKEN, VAULT_ROLE_ID, and
+// VAULT_SECRET_ID respectively when an option is unset. mount defaults to
+// "secret", Vault's default KV v2 mount point.
+func (p *VaultProvider) Configure(opts map[string]string) error {
+	p.addr = firstNonEmpty(opts["addr"], os.Getenv("VAULT_ADDR"))
+	if p.addr == "" {
+		return fmt.Errorf("vault: no address configured (set secrets.options.addr or VAULT_ADDR)")
+	}
+	p.mount = firstNonEmpty(opts["mount"], "secret")
+	p.token = firstNonEmpty(opts["token"], os.Getenv("VAULT_TOKEN"))
+	p.roleID = firstNonEmpty(opts["role_id"], os.Getenv("VAULT_ROLE_ID"))
+	p.secretID = firstNonEmpty(opts["secret_id"], os.Getenv("VAULT_SECRET_ID"))
+	if p.client == nil {
+		p.client = http.DefaultClient
+	}
+	return nil
+}
+
 func (p *VaultProvider) Available() bool {
-	_, err := exec.LookPath("vault")
-	return err == nil
+	return p.addr != "" && (p.token != "" || (p.roleID != "" && p.secretID != ""))
 }
 
 func (p *VaultProvider) Load(keys []string) (map[string]string, error) {
-	result := make(map[string]string, len(keys))
+	token, err := p.authToken()
+	if err != nil {
+		return nil, fmt.Errorf("vault: authenticating: %w", err)
+	}
 
+	result := make(map[string]string, len(keys))
 	for _, key := range keys {
-		// key format: "secret/data/myapp#field"
+		// key format: "myapp/config#field", rooted under the configured
+		// KV v2 mount (so the full API path is <mount>/data/<path>).
 		parts := strings.SplitN(key, "#", 2)
 		path := parts[0]
 		field := "value"
@@ -96,27 +145,85 @@ func (p *VaultProvider) Load(keys []string) (map[string]string, error) {
 			field = parts[1]
 		}
 
-		out, err := exec.Command("vault", "kv", "get", "-format=json", path).Output()
-		if err != nil {
-			return result, fmt.Errorf("vault: failed to read %q: %w", key, err)
-		}
-
 		var resp struct {
 			Data struct {
 				Data map[string]interface{} `json:"data"`
 			} `json:"data"`
 		}
-		if err := json.Unmarshal(out, &resp); err != nil {
-			return result, fmt.Errorf("vault: failed to parse response for %q: %w", key, err)
+		if err := p.request("GET", fmt.Sprintf("/v1/%s/data/%s", p.mount, path), token, nil, &resp); err != nil {
+			return result, fmt.Errorf("vault: failed to read %q: %w", key, err)
 		}
 
 		val, ok := resp.Data.Data[field]
 		if !ok {
 			return result, fmt.Errorf("vault: field %q not found in %q", field, path)
 		}
-
 		result[key] = fmt.Sprintf("%v", val)
 	}
 
 	return result, nil
 }
+
+// authToken returns the configured static token, or logs in via AppRole
+// and returns the client token Vault issues.
+func (p *VaultProvider) authToken() (string, error) {
+	if p.token != "" {
+		return p.token, nil
+	}
+
+	body := map[string]string{"role_id": p.roleID, "secret_id": p.secretID}
+	var resp struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	if err := p.request("POST", "/v1/auth/approle/login", "", body, &resp); err != nil {
+		return "", err
+	}
+	if resp.Auth.ClientToken == "" {
+		return "", fmt.Errorf("approle login returned no client token")
+	}
+	return resp.Auth.ClientToken, nil
+}
+
+func (p *VaultProvider) request(method, path, token string, body interface{}, out interface{}) error {
+	var reqBody *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	} else {
+		reqBody = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(p.addr, "/")+path, reqBody)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
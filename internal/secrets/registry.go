@@ -21,6 +21,8 @@ func NewRegistry() *Registry {
 	r.Register(&OnePasswordProvider{})
 	r.Register(&OnePasswordEnvProvider{})
 	r.Register(&VaultProvider{})
+	r.Register(&AWSSecretsManagerProvider{})
+	r.Register(NewChainProvider("chain", &VaultProvider{}, &OnePasswordProvider{}, &EnvProvider{}))
 
 	return r
 }
@@ -30,6 +32,15 @@ func (r *Registry) Register(p types.SecretProvider) {
 	r.providers[p.Name()] = p
 }
 
+// Providers returns every registered provider, built-in and plugin alike.
+func (r *Registry) Providers() []types.SecretProvider {
+	providers := make([]types.SecretProvider, 0, len(r.providers))
+	for _, p := range r.providers {
+		providers = append(providers, p)
+	}
+	return providers
+}
+
 // Get returns a provider by name.
 func (r *Registry) Get(name string) (types.SecretProvider, error) {
 	p, ok := r.providers[name]
@@ -50,6 +61,12 @@ func (r *Registry) LoadSecrets(cfg types.SecretsConfig, keys []string) (map[stri
 		return nil, err
 	}
 
+	if cp, ok := provider.(ConfigurableProvider); ok {
+		if err := cp.Configure(cfg.Options); err != nil {
+			return nil, fmt.Errorf("configuring secret provider %q: %w", cfg.Provider, err)
+		}
+	}
+
 	if !provider.Available() {
 		return nil, fmt.Errorf("secret provider %q is not available on this system", cfg.Provider)
 	}
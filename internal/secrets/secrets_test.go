@@ -51,3 +51,40 @@ func TestRegistry_Get(t *testing.T) {
 		t.Error("nonexistent provider should error")
 	}
 }
+
+func TestRegistry_Providers(t *testing.T) {
+	r := NewRegistry()
+
+	names := make(map[string]bool)
+	for _, p := range r.Providers() {
+		names[p.Name()] = true
+	}
+
+	if !names["env"] {
+		t.Error("expected built-in env provider in Providers()")
+	}
+	if !names["aws-secretsmanager"] {
+		t.Error("expected built-in aws-secretsmanager provider in Providers()")
+	}
+	if !names["chain"] {
+		t.Error("expected built-in chain provider in Providers()")
+	}
+}
+
+func TestChainProvider_FallsBackToFirstAvailable(t *testing.T) {
+	os.Setenv("CS_TEST_KEY_1", "value1")
+	defer os.Unsetenv("CS_TEST_KEY_1")
+
+	chain := NewChainProvider("chain", &VaultProvider{}, &EnvProvider{})
+	if !chain.Available() {
+		t.Fatal("chain should be available via the env fallback")
+	}
+
+	result, err := chain.Load([]string{"CS_TEST_KEY_1"})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if result["CS_TEST_KEY_1"] != "value1" {
+		t.Errorf("expected value1, got %s", result["CS_TEST_KEY_1"])
+	}
+}
@@ -0,0 +1,33 @@
+package template
+
+import "context"
+
+// FetchFunc resolves the current value for a single reference.
+type FetchFunc func(ctx context.Context) (string, error)
+
+// providerDependency adapts a provider-backed fetch closure to the
+// Dependency interface. Stop runs the stop func given to
+// NewProviderDependency, and does nothing when that func is nil.
+type providerDependency struct {
+	ref   string
+	fetch FetchFunc
+	stop  func()
+}
+
+// NewProviderDependency builds a Dependency from a fetch closure. stop may
+// be nil if the dependency holds no resources that need releasing.
+func NewProviderDependency(ref string, fetch FetchFunc, stop func()) Dependency {
+	return &providerDependency{ref: ref, fetch: fetch, stop: stop}
+}
+
+func (d *providerDependency) Ref() string { return d.ref }
+
+func (d *providerDependency) Fetch(ctx context.Context) (string, error) {
+	return d.fetch(ctx)
+}
+
+func (d *providerDependency) Stop() {
+	if d.stop != nil {
+		d.stop()
+	}
+}
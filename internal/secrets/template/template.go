@@ -0,0 +1,356 @@
+// Package template renders consul-template-style files that interpolate
+// secret references, watching those references for changes and
+// atomically re-rendering the output while the sandbox session is alive.
+package template
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// Dependency is a single secret or environment reference a rendered
+// template depends on. Implementations are kept alive for the lifetime of
+// the Runner so they can poll (or subscribe to) the backing provider.
+type Dependency interface {
+	// Ref is the canonical string used to dedupe dependencies across
+	// templates, e.g. "secret:vault:secret/data/db#password".
+	Ref() string
+	// Fetch resolves the current value of the dependency.
+	Fetch(ctx context.Context) (string, error)
+	// Stop releases any resources held by the dependency (connections,
+	// watches, etc).
+	Stop()
+}
+
+// Resolver creates a Dependency for a reference string extracted from a
+// template: the call's kind ("secret", "env" or "file"), a colon, and its
+// argument, e.g. "env:USER".
+type Resolver func(ref string) (Dependency, error)
+
+// SignalFunc delivers a signal to the sandbox container, mirroring
+// "docker kill --signal". It is supplied by the caller so this package
+// has no Docker dependency of its own.
+type SignalFunc func(ctx context.Context, containerID, signal string) error
+
+// Spec describes a single template file to render.
+type Spec struct {
+	Source string      // path to the .tmpl source
+	Dest   string      // path to write the rendered output
+	Mode   os.FileMode // permissions for the rendered file
+	Signal string      // optional signal to send after a re-render, e.g. "SIGHUP"
+}
+
+var refPattern = regexp.MustCompile(`{{\s*(secret|env|file)\s+"([^"]+)"\s*}}`)
+
+// SecretRefs scans the template source at src and returns the distinct key
+// names referenced via {{ secret "..." }}, in first-seen order. Callers
+// that need to know which secret keys a template actually requires (e.g.
+// to pass a real key list to a SecretProvider, rather than guessing) use
+// this instead of parsing refPattern themselves.
+func SecretRefs(src string) ([]string, error) {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return nil, fmt.Errorf("reading template source: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range refPattern.FindAllStringSubmatch(string(data), -1) {
+		if m[1] != "secret" {
+			continue
+		}
+		if !seen[m[2]] {
+			seen[m[2]] = true
+			keys = append(keys, m[2])
+		}
+	}
+	return keys, nil
+}
+
+// Runner renders a set of Specs and keeps them up to date by polling their
+// dependencies at Interval and re-rendering on change.
+type Runner struct {
+	Specs       []Spec
+	Interval    time.Duration
+	ContainerID string
+	SendSignal  SignalFunc
+	Resolve     Resolver
+
+	mu     sync.Mutex
+	values map[string]string   // ref -> last fetched value, deduped across templates
+	deps   map[string]Dependency
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRunner creates a Runner for the given template specs.
+func NewRunner(specs []Spec, interval time.Duration, resolve Resolver) *Runner {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	return &Runner{
+		Specs:    specs,
+		Interval: interval,
+		Resolve:  resolve,
+		values:   make(map[string]string),
+		deps:     make(map[string]Dependency),
+	}
+}
+
+// Start renders every template once, then begins polling dependencies in
+// the background and re-rendering on change until Stop is called.
+func (r *Runner) Start(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	r.cancel = cancel
+
+	if err := r.renderAll(runCtx); err != nil {
+		cancel()
+		return fmt.Errorf("initial template render: %w", err)
+	}
+
+	r.wg.Add(1)
+	go r.loop(runCtx)
+	return nil
+}
+
+// Stop tears down all watchers and background rendering. It never leaves
+// a partially written file behind.
+func (r *Runner) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, d := range r.deps {
+		d.Stop()
+	}
+}
+
+func (r *Runner) loop(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := r.renderChanged(ctx)
+			if err != nil || !changed {
+				continue
+			}
+			if r.SendSignal != nil {
+				r.sendSignals(ctx)
+			}
+		}
+	}
+}
+
+// renderAll resolves every dependency and writes every template,
+// regardless of whether the dependency's value changed.
+func (r *Runner) renderAll(ctx context.Context) error {
+	for _, spec := range r.Specs {
+		if err := r.renderOne(ctx, spec); err != nil {
+			return fmt.Errorf("rendering %s: %w", spec.Source, err)
+		}
+	}
+	return nil
+}
+
+// renderChanged re-fetches every dependency and only rewrites templates
+// whose resolved values actually changed, returning whether anything
+// was re-rendered.
+func (r *Runner) renderChanged(ctx context.Context) (bool, error) {
+	changed := false
+	for _, spec := range r.Specs {
+		refs, err := r.dependenciesFor(spec)
+		if err != nil {
+			return changed, err
+		}
+
+		dirty := false
+		for _, ref := range refs {
+			dep, err := r.dependency(ref)
+			if err != nil {
+				return changed, err
+			}
+			val, err := dep.Fetch(ctx)
+			if err != nil {
+				return changed, fmt.Errorf("fetching %q: %w", ref, err)
+			}
+
+			r.mu.Lock()
+			if r.values[ref] != val {
+				r.values[ref] = val
+				dirty = true
+			}
+			r.mu.Unlock()
+		}
+
+		if dirty {
+			if err := r.render(spec); err != nil {
+				return changed, err
+			}
+			changed = true
+		}
+	}
+	return changed, nil
+}
+
+func (r *Runner) renderOne(ctx context.Context, spec Spec) error {
+	refs, err := r.dependenciesFor(spec)
+	if err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		dep, err := r.dependency(ref)
+		if err != nil {
+			return err
+		}
+		val, err := dep.Fetch(ctx)
+		if err != nil {
+			return fmt.Errorf("fetching %q: %w", ref, err)
+		}
+		r.mu.Lock()
+		r.values[ref] = val
+		r.mu.Unlock()
+	}
+	return r.render(spec)
+}
+
+// dependency returns the cached Dependency for ref, creating it via
+// Resolve on first use (deduped across every template that references it).
+func (r *Runner) dependency(ref string) (Dependency, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if d, ok := r.deps[ref]; ok {
+		return d, nil
+	}
+	d, err := r.Resolve(ref)
+	if err != nil {
+		return nil, fmt.Errorf("resolving dependency %q: %w", ref, err)
+	}
+	r.deps[ref] = d
+	return d, nil
+}
+
+func (r *Runner) dependenciesFor(spec Spec) ([]string, error) {
+	data, err := os.ReadFile(spec.Source)
+	if err != nil {
+		return nil, fmt.Errorf("reading template source: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var refs []string
+	for _, m := range refPattern.FindAllStringSubmatch(string(data), -1) {
+		ref := m[1] + ":" + m[2]
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+// render expands spec.Source against the Runner's current value cache and
+// atomically replaces spec.Dest. It never leaves a partially written file
+// and never logs the rendered content.
+func (r *Runner) render(spec Spec) error {
+	data, err := os.ReadFile(spec.Source)
+	if err != nil {
+		return fmt.Errorf("reading template source: %w", err)
+	}
+
+	funcs := template.FuncMap{
+		"secret": func(ref string) (string, error) { return r.lookup("secret:" + ref) },
+		"env":    func(ref string) (string, error) { return r.lookup("env:" + ref) },
+		"file":   func(ref string) (string, error) { return r.lookup("file:" + ref) },
+	}
+
+	tmpl, err := template.New(filepath.Base(spec.Source)).Funcs(funcs).Parse(string(data))
+	if err != nil {
+		return fmt.Errorf("parsing template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		return fmt.Errorf("executing template: %w", err)
+	}
+
+	mode := spec.Mode
+	if mode == 0 {
+		mode = 0600
+	}
+
+	if err := os.MkdirAll(filepath.Dir(spec.Dest), 0700); err != nil {
+		return fmt.Errorf("creating destination dir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(spec.Dest), ".cs-tmpl-*")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("chmod temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("closing temp file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, spec.Dest); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("renaming into place: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Runner) lookup(ref string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	val, ok := r.values[ref]
+	if !ok {
+		return "", fmt.Errorf("value for %q not yet resolved", ref)
+	}
+	return val, nil
+}
+
+func (r *Runner) sendSignals(ctx context.Context) {
+	if r.ContainerID == "" {
+		return
+	}
+	sent := make(map[string]bool)
+	for _, spec := range r.Specs {
+		if spec.Signal == "" {
+			continue
+		}
+		key := spec.Signal
+		if sent[key] {
+			continue
+		}
+		sent[key] = true
+		_ = r.SendSignal(ctx, r.ContainerID, spec.Signal)
+	}
+}
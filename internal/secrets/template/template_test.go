@@ -0,0 +1,63 @@
+package template
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunner_RenderAll(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "db.env.tmpl")
+	dest := filepath.Join(dir, "rendered", "db.env")
+
+	if err := os.WriteFile(src, []byte(`PASSWORD={{ secret "vault:secret/data/db#password" }}`), 0600); err != nil {
+		t.Fatalf("writing template source: %v", err)
+	}
+
+	resolve := func(ref string) (Dependency, error) {
+		return NewProviderDependency(ref, func(context.Context) (string, error) {
+			return "hunter2", nil
+		}, nil), nil
+	}
+
+	r := NewRunner([]Spec{{Source: src, Dest: dest, Mode: 0600}}, 0, resolve)
+	if err := r.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer r.Stop()
+
+	data, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("reading rendered file: %v", err)
+	}
+	if string(data) != "PASSWORD=hunter2" {
+		t.Errorf("rendered = %q, want %q", string(data), "PASSWORD=hunter2")
+	}
+}
+
+func TestRunner_NeverLeavesPartialFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "broken.tmpl")
+	dest := filepath.Join(dir, "out")
+
+	if err := os.WriteFile(src, []byte(`{{ secret "env:MISSING" }}`), 0600); err != nil {
+		t.Fatalf("writing template source: %v", err)
+	}
+
+	resolve := func(ref string) (Dependency, error) {
+		return NewProviderDependency(ref, func(context.Context) (string, error) {
+			return "", os.ErrNotExist
+		}, nil), nil
+	}
+
+	r := NewRunner([]Spec{{Source: src, Dest: dest}}, 0, resolve)
+	if err := r.Start(context.Background()); err == nil {
+		t.Fatal("expected Start to fail when a dependency cannot be fetched")
+	}
+
+	if _, err := os.Stat(dest); !os.IsNotExist(err) {
+		t.Errorf("destination file should not exist after a failed render, stat err = %v", err)
+	}
+}
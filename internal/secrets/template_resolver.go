@@ -0,0 +1,74 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MakazhanAlpamys/claudeshield/internal/secrets/template"
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// NewTemplateResolver builds a template.Resolver backed by the registry's
+// providers. Reference strings carry their kind as a prefix added by the
+// template package ("secret:...", "env:...", "file:...") so the right
+// provider can be picked without re-parsing the template source.
+func (r *Registry) NewTemplateResolver() template.Resolver {
+	return func(ref string) (template.Dependency, error) {
+		kind, value, ok := strings.Cut(ref, ":")
+		if !ok {
+			return nil, fmt.Errorf("malformed template reference %q", ref)
+		}
+
+		switch kind {
+		case "env":
+			return template.NewProviderDependency(ref, func(context.Context) (string, error) {
+				return os.Getenv(value), nil
+			}, nil), nil
+
+		case "file":
+			return template.NewProviderDependency(ref, func(context.Context) (string, error) {
+				data, err := os.ReadFile(value)
+				if err != nil {
+					return "", err
+				}
+				return strings.TrimRight(string(data), "\n"), nil
+			}, nil), nil
+
+		case "secret":
+			provider, key, err := r.providerForRef(value)
+			if err != nil {
+				return nil, err
+			}
+			return template.NewProviderDependency(ref, func(context.Context) (string, error) {
+				vals, err := provider.Load([]string{key})
+				if err != nil {
+					return "", fmt.Errorf("%s: %w", provider.Name(), err)
+				}
+				return vals[key], nil
+			}, nil), nil
+
+		default:
+			return nil, fmt.Errorf("unknown template reference kind %q", kind)
+		}
+	}
+}
+
+// providerForRef picks a provider and provider-local key for a "secret"
+// reference. Refs prefixed with a known scheme ("vault:", "op://") select
+// that provider explicitly; anything else is read through the "env"
+// provider. The project's configured secrets.provider is not consulted.
+func (r *Registry) providerForRef(ref string) (provider types.SecretProvider, key string, err error) {
+	switch {
+	case strings.HasPrefix(ref, "vault:"):
+		p, err := r.Get("vault")
+		return p, strings.TrimPrefix(ref, "vault:"), err
+	case strings.HasPrefix(ref, "op://"):
+		p, err := r.Get("1password")
+		return p, ref, err
+	default:
+		p, err := r.Get("env")
+		return p, ref, err
+	}
+}
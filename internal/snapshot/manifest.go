@@ -0,0 +1,28 @@
+// Package snapshot implements content-addressable manifests of an agent's
+// worktree, sandbox config, and policy state, so a past agent run can be
+// referenced by digest and reproducibly restored later.
+package snapshot
+
+import (
+	"time"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+// Manifest is the immutable record of an agent's state at the moment a
+// snapshot was taken. Digest is the SHA-256 of the manifest's own
+// canonical JSON (computed with Digest left empty), so two manifests with
+// identical content always collide onto the same address.
+type Manifest struct {
+	Digest         string              `json:"digest"`
+	ParentDigest   string              `json:"parent_digest,omitempty"`
+	AgentName      string              `json:"agent_name"`
+	GitCommit      string              `json:"git_commit"`
+	ContainerImage string              `json:"container_image"`
+	SandboxConfig  types.SandboxConfig `json:"sandbox_config"`
+	PolicyRules    types.RulesConfig   `json:"policy_rules"`
+	// SecretRefs records which secrets were injected, by name only —
+	// never the resolved values.
+	SecretRefs []string  `json:"secret_refs,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,142 @@
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// DirName is the subdirectory of .claudeshield where snapshots and their
+// aliases live.
+const DirName = "snapshots"
+
+// Store addresses manifests by the SHA-256 digest of their content under
+// <projectDir>/.claudeshield/snapshots/<digest>/manifest.json.
+type Store struct {
+	root string
+}
+
+// NewStore creates a Store rooted at projectDir's .claudeshield directory.
+func NewStore(projectDir string) *Store {
+	return &Store{root: filepath.Join(projectDir, ".claudeshield", DirName)}
+}
+
+// Create computes m's digest and writes it to disk, returning the
+// manifest with Digest populated. Any ParentDigest the caller set is
+// preserved as part of the hashed content, giving each snapshot a lineage.
+func (s *Store) Create(m Manifest) (*Manifest, error) {
+	m.Digest = ""
+	digest, err := digestOf(m)
+	if err != nil {
+		return nil, fmt.Errorf("computing snapshot digest: %w", err)
+	}
+	m.Digest = digest
+
+	dir := filepath.Join(s.root, digest)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("creating snapshot dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "manifest.json"), data, 0600); err != nil {
+		return nil, fmt.Errorf("writing manifest: %w", err)
+	}
+
+	return &m, nil
+}
+
+// Load reads the manifest for digestOrAlias, resolving aliases first.
+func (s *Store) Load(digestOrAlias string) (*Manifest, error) {
+	digest, err := s.Resolve(digestOrAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.root, digest, "manifest.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("snapshot %q not found", digestOrAlias)
+		}
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// Alias records a short name for digest, mirroring Docker's plugin
+// --alias handling: aliases are looked up before falling back to treating
+// the input as a raw digest, and re-aliasing an existing name repoints it
+// rather than colliding.
+func (s *Store) Alias(digest, name string) error {
+	if _, err := s.Load(digest); err != nil {
+		return fmt.Errorf("aliasing unknown snapshot %q: %w", digest, err)
+	}
+
+	aliasDir := filepath.Join(s.root, "aliases")
+	if err := os.MkdirAll(aliasDir, 0700); err != nil {
+		return fmt.Errorf("creating alias dir: %w", err)
+	}
+	return os.WriteFile(filepath.Join(aliasDir, name), []byte(digest), 0600)
+}
+
+// Resolve returns the digest that digestOrAlias refers to, checking
+// aliases first.
+func (s *Store) Resolve(digestOrAlias string) (string, error) {
+	aliasPath := filepath.Join(s.root, "aliases", digestOrAlias)
+	if data, err := os.ReadFile(aliasPath); err == nil {
+		return string(data), nil
+	}
+
+	if _, err := os.Stat(filepath.Join(s.root, digestOrAlias)); err != nil {
+		return "", fmt.Errorf("snapshot %q not found", digestOrAlias)
+	}
+	return digestOrAlias, nil
+}
+
+// List returns every manifest in the store, newest first.
+func (s *Store) List() ([]Manifest, error) {
+	entries, err := os.ReadDir(s.root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing snapshots: %w", err)
+	}
+
+	var manifests []Manifest
+	for _, e := range entries {
+		if !e.IsDir() || e.Name() == "aliases" {
+			continue
+		}
+		m, err := s.Load(e.Name())
+		if err != nil {
+			continue
+		}
+		manifests = append(manifests, *m)
+	}
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].CreatedAt.After(manifests[j].CreatedAt)
+	})
+	return manifests, nil
+}
+
+// digestOf returns the hex-encoded SHA-256 of m's canonical JSON form.
+func digestOf(m Manifest) (string, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,83 @@
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/MakazhanAlpamys/claudeshield/pkg/types"
+)
+
+func TestStore_CreateAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+
+	m, err := store.Create(Manifest{
+		AgentName: "dev",
+		GitCommit: "deadbeef",
+		SandboxConfig: types.SandboxConfig{
+			MemoryLimit: "2g",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if m.Digest == "" {
+		t.Fatal("expected a non-empty digest")
+	}
+
+	loaded, err := store.Load(m.Digest)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if loaded.GitCommit != "deadbeef" {
+		t.Errorf("GitCommit = %q, want deadbeef", loaded.GitCommit)
+	}
+}
+
+func TestStore_IdenticalContentSameDigest(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+
+	base := Manifest{AgentName: "dev", GitCommit: "abc123"}
+
+	m1, err := store.Create(base)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	m2, err := store.Create(base)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if m1.Digest != m2.Digest {
+		t.Errorf("expected identical manifests to collide onto the same digest, got %s != %s", m1.Digest, m2.Digest)
+	}
+}
+
+func TestStore_AliasAndResolve(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+
+	m, err := store.Create(Manifest{AgentName: "dev", GitCommit: "abc123"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if err := store.Alias(m.Digest, "stable"); err != nil {
+		t.Fatalf("Alias: %v", err)
+	}
+
+	loaded, err := store.Load("stable")
+	if err != nil {
+		t.Fatalf("Load by alias: %v", err)
+	}
+	if loaded.Digest != m.Digest {
+		t.Errorf("Digest = %s, want %s", loaded.Digest, m.Digest)
+	}
+}
+
+func TestStore_AliasUnknownDigestFails(t *testing.T) {
+	store := NewStore(t.TempDir())
+	if err := store.Alias("nonexistent", "name"); err == nil {
+		t.Error("expected error aliasing an unknown digest")
+	}
+}
@@ -3,6 +3,7 @@ package tui
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -155,7 +156,7 @@ func (m Model) refreshData() tea.Cmd {
 func (m Model) refreshAudit() tea.Cmd {
 	return func() tea.Msg {
 		if m.auditLogDir != "" {
-			entries, err := audit.ReadSession(m.auditLogDir, "")
+			entries, err := audit.Query(m.auditLogDir, audit.QueryOpts{})
 			if err == nil {
 				return AuditMsg{Entries: entries}
 			}
@@ -293,6 +294,12 @@ func (m *Model) renderAudit() string {
 			entry.Command,
 			entry.Reason,
 		)
+		if entry.RunnerID != "" || entry.RequestID != "" {
+			line += fmt.Sprintf(" (runner=%s req=%s)", entry.RunnerID, entry.RequestID)
+		}
+		if len(entry.MatchedZones) > 0 {
+			line += " zones=" + strings.Join(entry.MatchedZones, ",")
+		}
 		content += line + "\n"
 	}
 
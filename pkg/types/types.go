@@ -31,31 +31,101 @@ type PolicyAction string
 const (
 	ActionAllow PolicyAction = "allow"
 	ActionBlock PolicyAction = "block"
+	// ActionDeny is a synonym for ActionBlock accepted in a Rule's scoped
+	// Actions list, matching the "deny" spelling community bundles and the
+	// policy hub tend to use for exec-scope rules.
+	ActionDeny  PolicyAction = "deny"
 	ActionAudit PolicyAction = "audit"
+	// ActionWarn marks an audit-scope action that should be logged at a
+	// reduced severity without itself affecting whether a command runs.
+	ActionWarn PolicyAction = "warn"
 	ActionPause PolicyAction = "pause"
 )
 
+// ScopedAction overrides a Rule's effect within one enforcement scope, so
+// a single rule can e.g. deny execution ({Scope: "exec", Action: ActionDeny})
+// while only warning in the audit log ({Scope: "audit", Action: ActionWarn}).
+// A scope with no entry here does not use the Rule's plain Action field: the
+// exec scope falls back to block for a Rules.Block entry, and the audit
+// scope to the resolved exec-scope action.
+type ScopedAction struct {
+	Scope  string       `yaml:"scope"  json:"scope"`
+	Action PolicyAction `yaml:"action" json:"action"`
+}
+
 // Rule defines a single policy rule for command/file filtering.
 type Rule struct {
 	Pattern string       `yaml:"pattern" json:"pattern"`
 	Action  PolicyAction `yaml:"action"  json:"action"`
 	Reason  string       `yaml:"reason"  json:"reason,omitempty"`
+	// Actions optionally refines Action per enforcement scope ("exec",
+	// "audit", ...). See ScopedAction and policy.Engine.applyEnforcement.
+	Actions []ScopedAction `yaml:"actions,omitempty" json:"actions,omitempty"`
 }
 
+// EnforcementMode is a project-wide override of how matched block rules
+// actually behave, letting an operator roll out new rules without them
+// biting yet.
+type EnforcementMode string
+
+const (
+	// EnforcementEnforce is the default: a rule's scoped/plain action runs
+	// exactly as configured.
+	EnforcementEnforce EnforcementMode = "enforce"
+	// EnforcementDryRun lets every command proceed regardless of what a
+	// rule would do, while still recording the would-be decision on the
+	// Result (see policy.Result.WouldBlock) for later analysis.
+	EnforcementDryRun EnforcementMode = "dryrun"
+	// EnforcementWarn behaves like EnforcementDryRun but signals operator
+	// intent to be notified rather than silently collecting data.
+	EnforcementWarn EnforcementMode = "warn"
+)
+
 // SandboxConfig defines sandbox isolation settings.
 type SandboxConfig struct {
-	Mount       string   `yaml:"mount"        json:"mount"`
-	Network     bool     `yaml:"network"      json:"network"`
-	ReadOnly    []string `yaml:"read_only"    json:"read_only,omitempty"`
-	UseGVisor   bool     `yaml:"use_gvisor"   json:"use_gvisor"`
-	MemoryLimit string   `yaml:"memory_limit" json:"memory_limit,omitempty"`
-	CPULimit    float64  `yaml:"cpu_limit"    json:"cpu_limit,omitempty"`
+	Mount       string            `yaml:"mount"        json:"mount"`
+	Network     bool              `yaml:"network"      json:"network"`
+	ReadOnly    []string          `yaml:"read_only"    json:"read_only,omitempty"`
+	UseGVisor   bool              `yaml:"use_gvisor"   json:"use_gvisor"`
+	MemoryLimit string            `yaml:"memory_limit" json:"memory_limit,omitempty"`
+	CPULimit    float64           `yaml:"cpu_limit"    json:"cpu_limit,omitempty"`
+	// Rootless connects to a user-level Docker/Podman socket instead of the
+	// system daemon, drops CapAdd, and requires /etc/subuid and /etc/subgid
+	// ranges for the current user. The container itself gets no uid/gid
+	// remap from ClaudeShield (see sandbox.applyRootless).
+	Rootless bool `yaml:"rootless" json:"rootless,omitempty"`
+	// Env and Labels may reference runtime identity with downward-API-style
+	// field refs, e.g. "${session.id}" or "${agent.name}", resolved by
+	// sandbox.resolveRefs at container-create time.
+	Env    map[string]string `yaml:"env"    json:"env,omitempty"`
+	Labels map[string]string `yaml:"labels" json:"labels,omitempty"`
+	// Devices lists CDI (Container Device Interface) device specs to attach,
+	// e.g. "nvidia.com/gpu=0" or "vendor.com/fpga=all". Each is checked
+	// against RulesConfig.Devices before being resolved through the host's
+	// CDI registry.
+	Devices []string `yaml:"devices" json:"devices,omitempty"`
+	// StopTimeout is how long sandbox.SignalProxy waits, in seconds, after
+	// forwarding SIGINT/SIGTERM to the container before escalating to a
+	// SIGKILL-and-remove teardown. Defaults to 10 when zero.
+	StopTimeout int `yaml:"stop_timeout,omitempty" json:"stop_timeout,omitempty"`
 }
 
 // SecretsConfig defines which secrets provider to use.
 type SecretsConfig struct {
-	Provider string            `yaml:"provider" json:"provider"`
-	Options  map[string]string `yaml:"options"  json:"options,omitempty"`
+	Provider  string            `yaml:"provider" json:"provider"`
+	Options   map[string]string `yaml:"options"  json:"options,omitempty"`
+	Templates []SecretTemplate  `yaml:"templates,omitempty" json:"templates,omitempty"`
+}
+
+// SecretTemplate declares a consul-template-style file to render before
+// the sandbox starts (e.g. a kubeconfig, .npmrc, or TOML config built from
+// one or more secrets) and mount read-only into the container, rather than
+// flattening everything to env vars. See internal/secrets/template.
+type SecretTemplate struct {
+	Src    string `yaml:"src"    json:"src"`
+	Dest   string `yaml:"dest"   json:"dest"`
+	Mode   uint32 `yaml:"mode,omitempty"   json:"mode,omitempty"`
+	Signal string `yaml:"signal,omitempty" json:"signal,omitempty"`
 }
 
 // AuditConfig defines audit logging settings.
@@ -63,20 +133,53 @@ type AuditConfig struct {
 	Enabled  bool   `yaml:"enabled"   json:"enabled"`
 	LogDir   string `yaml:"log_dir"   json:"log_dir"`
 	CloudURL string `yaml:"cloud_url" json:"cloud_url,omitempty"`
+	// Sinks are additional destinations audit entries fan out to, beyond
+	// the always-on local JSONL sink under LogDir. See internal/audit.
+	Sinks []SinkConfig `yaml:"sinks,omitempty" json:"sinks,omitempty"`
+}
+
+// SinkConfig configures one additional audit sink.
+type SinkConfig struct {
+	// Type selects the sink implementation: "syslog", "journald", or "http".
+	Type string `yaml:"type" json:"type"`
+	// Address is the sink's destination for syslog (e.g. "udp://host:514",
+	// empty for the local syslog daemon).
+	Address string `yaml:"address,omitempty" json:"address,omitempty"`
+	// URL is the endpoint an "http" sink POSTs newline-delimited JSON to.
+	// Defaults to CloudURL when empty.
+	URL string `yaml:"url,omitempty" json:"url,omitempty"`
 }
 
 // ProjectConfig is the top-level configuration from .claudeshield.yaml
 type ProjectConfig struct {
+	// Runtime selects the OCI runtime backend: "docker", "containerd", or
+	// "podman" (podman speaks Docker's API, so it reuses the docker backend
+	// against whatever socket DOCKER_HOST names, e.g.
+	// $XDG_RUNTIME_DIR/podman/podman.sock). Defaults to "docker".
+	Runtime string        `yaml:"runtime,omitempty" json:"runtime,omitempty"`
 	Sandbox SandboxConfig `yaml:"sandbox" json:"sandbox"`
 	Rules   RulesConfig   `yaml:"rules"   json:"rules"`
 	Secrets SecretsConfig `yaml:"secrets" json:"secrets"`
 	Audit   AuditConfig   `yaml:"audit"   json:"audit"`
+	// Bundles pins community policy hub bundles ("python-dev",
+	// "node-safe@1.2.0") merged onto Rules at load time by
+	// hub.Hub.MergeInto. See internal/hub.
+	Bundles []string `yaml:"bundles,omitempty" json:"bundles,omitempty"`
+	// EnforcementMode overrides every rule's effective exec-scope action at
+	// runtime: "dryrun"/"warn" let matched block rules proceed anyway while
+	// still recording what would have happened. Empty means "enforce".
+	EnforcementMode EnforcementMode `yaml:"enforcement_mode,omitempty" json:"enforcement_mode,omitempty"`
 }
 
 // RulesConfig groups allow and block rules.
 type RulesConfig struct {
 	Allow []Rule `yaml:"allow" json:"allow"`
 	Block []Rule `yaml:"block" json:"block"`
+	// Devices is an allow-list of CDI device spec glob patterns, e.g.
+	// "nvidia.com/gpu=*". A requested device not matching any pattern here
+	// is rejected by policy.Engine.EvaluateDevice. Empty means no devices
+	// may be attached.
+	Devices []string `yaml:"devices" json:"devices,omitempty"`
 }
 
 // AuditEntry represents a single audit log entry.
@@ -90,15 +193,57 @@ type AuditEntry struct {
 	Action      PolicyAction `json:"action"`
 	Reason      string       `json:"reason,omitempty"`
 	RulePattern string       `json:"rule_pattern,omitempty"`
+	// RequestID correlates every audit entry produced by one host-level
+	// tool invocation (e.g. one claude-code command exec), set via
+	// policy.WithRequestID on the context the evaluation ran under.
+	RequestID string `json:"request_id,omitempty"`
+	// RunnerID identifies the agent goroutine/worker that made the
+	// request, so concurrent agents' events don't look interleaved from a
+	// single source. Set via policy.WithRunnerID.
+	RunnerID string `json:"runner_id,omitempty"`
+	// MatchedZones names the parts of the input that triggered the rule,
+	// e.g. ["args[2]", "env.PATH"] for a command, ["basename"] for a file
+	// path — lets an operator tell a binary-name block from an
+	// argument-content block at a glance. See policy.Result.MatchedZones.
+	MatchedZones []string `json:"matched_zones,omitempty"`
+	// WouldBlock, EnforcementMode, and AuditSeverity mirror the same fields
+	// on policy.Result, so an entry recorded under a "dryrun"/"warn"
+	// EnforcementMode still shows what the rule would have done even though
+	// Action reflects that the command was actually let through.
+	WouldBlock      bool            `json:"would_block,omitempty"`
+	EnforcementMode EnforcementMode `json:"enforcement_mode,omitempty"`
+	AuditSeverity   PolicyAction    `json:"audit_severity,omitempty"`
+	// PrevHash and Hash chain this entry to the one before it in the same
+	// log file, so a compromised agent can't silently rewrite history
+	// without the break showing up under `claudeshield audit verify`. See
+	// audit.Logger.Log and audit.VerifyChain.
+	PrevHash string `json:"prev_hash,omitempty"`
+	Hash     string `json:"hash,omitempty"`
 }
 
-// Checkpoint represents a rollback point (Docker layer snapshot).
+// CheckpointMode selects how a Checkpoint captured container state.
+type CheckpointMode string
+
+const (
+	// CheckpointModeFS commits the container's filesystem layers only
+	// (the original behavior). Running processes, open file descriptors,
+	// and in-flight network connections are not preserved.
+	CheckpointModeFS CheckpointMode = "fs"
+	// CheckpointModeCRIU dumps the full process tree state via CRIU, so a
+	// restore resumes an in-flight agent rather than starting it fresh.
+	CheckpointModeCRIU CheckpointMode = "criu"
+)
+
+// Checkpoint represents a rollback point. CheckpointMode determines whether
+// ImageID (fs mode) or DumpPath (criu mode) is populated.
 type Checkpoint struct {
-	ID          string    `json:"id"`
-	SessionID   string    `json:"session_id"`
-	ImageID     string    `json:"image_id"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID             string         `json:"id"`
+	SessionID      string         `json:"session_id"`
+	CheckpointMode CheckpointMode `json:"checkpoint_mode"`
+	ImageID        string         `json:"image_id,omitempty"`
+	DumpPath       string         `json:"dump_path,omitempty"`
+	Description    string         `json:"description"`
+	CreatedAt      time.Time      `json:"created_at"`
 }
 
 // SecretProvider is the interface for secret providers.